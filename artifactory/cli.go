@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jfrog/jfrog-cli-core/artifactory/commands/container"
 	"github.com/jfrog/jfrog-cli-core/artifactory/commands/dotnet"
@@ -22,6 +23,15 @@ import (
 	coreCommonCommands "github.com/jfrog/jfrog-cli-core/common/commands"
 	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/utils/ioutils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/buildenv"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/detailedsummary"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/diff"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/downloadcache"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/downloaditems"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/downloadlock"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/resumabledownload"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/uploadstate"
+	"github.com/jfrog/jfrog-cli/completion/dynamic"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/accesstokencreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/builddockercreate"
 	dotnetdocs "github.com/jfrog/jfrog-cli/docs/artifactory/dotnet"
@@ -38,8 +48,11 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/usercreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/userscreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/usersdelete"
+	cacheutils "github.com/jfrog/jfrog-cli/utils/cache"
 	logUtils "github.com/jfrog/jfrog-cli/utils/log"
 	"github.com/jfrog/jfrog-cli/utils/progressbar"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-cli/utils/tracing"
 	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
 	"github.com/jszwec/csvutil"
 
@@ -78,6 +91,7 @@ import (
 	curldocs "github.com/jfrog/jfrog-cli/docs/artifactory/curl"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/delete"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/deleteprops"
+	diffdocs "github.com/jfrog/jfrog-cli/docs/artifactory/diff"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dockerpromote"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dockerpull"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dockerpush"
@@ -124,6 +138,7 @@ import (
 	distributionServicesUtils "github.com/jfrog/jfrog-client-go/distribution/services/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
@@ -161,7 +176,7 @@ func GetCommands() []cli.Command {
 			HelpName:     corecommon.CreateUsage("rt upload", upload.Description, upload.Usage),
 			UsageText:    upload.Arguments,
 			ArgsUsage:    common.CreateEnvVars(upload.EnvVar),
-			BashComplete: corecommon.CreateBashCompletionFunc(),
+			BashComplete: dynamic.CreateArtifactoryPathsCompletionFunc(),
 			Action: func(c *cli.Context) error {
 				return uploadCmd(c)
 			},
@@ -174,7 +189,7 @@ func GetCommands() []cli.Command {
 			HelpName:     corecommon.CreateUsage("rt download", download.Description, download.Usage),
 			UsageText:    download.Arguments,
 			ArgsUsage:    common.CreateEnvVars(download.EnvVar),
-			BashComplete: corecommon.CreateBashCompletionFunc(),
+			BashComplete: dynamic.CreateArtifactoryPathsCompletionFunc(),
 			Action: func(c *cli.Context) error {
 				return downloadCmd(c)
 			},
@@ -187,7 +202,7 @@ func GetCommands() []cli.Command {
 			HelpName:     corecommon.CreateUsage("rt move", move.Description, move.Usage),
 			UsageText:    move.Arguments,
 			ArgsUsage:    common.CreateEnvVars(),
-			BashComplete: corecommon.CreateBashCompletionFunc(),
+			BashComplete: dynamic.CreateArtifactoryPathsCompletionFunc(),
 			Action: func(c *cli.Context) error {
 				return moveCmd(c)
 			},
@@ -200,7 +215,7 @@ func GetCommands() []cli.Command {
 			HelpName:     corecommon.CreateUsage("rt copy", copydocs.Description, copydocs.Usage),
 			UsageText:    copydocs.Arguments,
 			ArgsUsage:    common.CreateEnvVars(),
-			BashComplete: corecommon.CreateBashCompletionFunc(),
+			BashComplete: dynamic.CreateArtifactoryPathsCompletionFunc(),
 			Action: func(c *cli.Context) error {
 				return copyCmd(c)
 			},
@@ -226,11 +241,23 @@ func GetCommands() []cli.Command {
 			HelpName:     corecommon.CreateUsage("rt search", search.Description, search.Usage),
 			UsageText:    search.Arguments,
 			ArgsUsage:    common.CreateEnvVars(),
-			BashComplete: corecommon.CreateBashCompletionFunc(),
+			BashComplete: dynamic.CreateArtifactoryPathsCompletionFunc(),
 			Action: func(c *cli.Context) error {
 				return searchCmd(c)
 			},
 		},
+		{
+			Name:         "diff",
+			Flags:        cliutils.GetCommandFlags(cliutils.Diff),
+			Description:  diffdocs.Description,
+			HelpName:     corecommon.CreateUsage("rt diff", diffdocs.Description, diffdocs.Usage),
+			UsageText:    diffdocs.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return diffCmd(c)
+			},
+		},
 		{
 			Name:         "set-props",
 			Flags:        cliutils.GetCommandFlags(cliutils.Properties),
@@ -1067,7 +1094,9 @@ func createArtifactoryDetailsByFlags(c *cli.Context, distribution bool) (*coreCo
 			return nil, errors.New("the --url option is mandatory")
 		}
 	}
-
+	cliutils.AddServerDetailsSecrets(artDetails)
+	cliutils.SetCommandServerDetails(c, artDetails)
+	tracing.SetCommandAttribute("jfrog.server_id", artDetails.ServerId)
 	return artDetails, nil
 }
 
@@ -1237,7 +1266,7 @@ func mvnLegacyCmd(c *cli.Context) error {
 	goals := strings.Split(c.Args().Get(0), " ")
 	mvnCmd := mvn.NewMvnCommand().SetConfiguration(configuration).SetConfigPath(c.Args().Get(1)).SetGoals(goals).SetThreads(threads)
 
-	return commands.Exec(mvnCmd)
+	return execAndReportServer(c, mvnCmd)
 }
 
 func mvnCmd(c *cli.Context) error {
@@ -1277,7 +1306,7 @@ func mvnCmd(c *cli.Context) error {
 			return err
 		}
 		mvnCmd := mvn.NewMvnCommand().SetConfiguration(buildConfiguration).SetConfigPath(configFilePath).SetGoals(filteredMavenArgs).SetThreads(threads).SetInsecureTls(insecureTls).SetDetailedSummary(detailedSummary)
-		err = commands.Exec(mvnCmd)
+		err = execAndReportServer(c, mvnCmd)
 		if err != nil {
 			return err
 		}
@@ -1322,7 +1351,7 @@ func gradleCmd(c *cli.Context) error {
 			return err
 		}
 		gradleCmd := gradle.NewGradleCommand().SetConfiguration(buildConfiguration).SetTasks(strings.Join(filteredGradleArgs, " ")).SetConfigPath(configFilePath).SetThreads(threads).SetDetailedSummary(detailedSummary)
-		err = commands.Exec(gradleCmd)
+		err = execAndReportServer(c, gradleCmd)
 		if err != nil {
 			return err
 		}
@@ -1339,7 +1368,7 @@ func PrintDetailedSummaryReport(c *cli.Context, originalErr error, result *comma
 		return errorutils.CheckError(errors.New("Empty reader - no files paths."))
 	}
 	defer os.Remove(result.Reader().GetFilesPaths()[0])
-	err := cliutils.PrintDetailedSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), true, originalErr)
+	err := cliutils.PrintDetailedSummaryReport(c, result.SuccessCount(), result.FailCount(), result.Reader(), true, originalErr)
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
@@ -1360,7 +1389,7 @@ func gradleLegacyCmd(c *cli.Context) error {
 	gradleCmd := gradle.NewGradleCommand()
 	gradleCmd.SetConfiguration(configuration).SetTasks(c.Args().Get(0)).SetConfigPath(c.Args().Get(1)).SetThreads(threads)
 
-	return commands.Exec(gradleCmd)
+	return execAndReportServer(c, gradleCmd)
 }
 
 func dockerPromoteCmd(c *cli.Context) error {
@@ -1411,7 +1440,7 @@ func containerPushCmd(c *cli.Context, containerManagerType containerutils.Contai
 	}
 	if dockerPushCommand.IsDetailedSummary() {
 		result := dockerPushCommand.Result()
-		return cliutils.PrintDetailedSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), true, err)
+		return cliutils.PrintDetailedSummaryReport(c, result.SuccessCount(), result.FailCount(), result.Reader(), true, err)
 	}
 	return nil
 }
@@ -1504,7 +1533,7 @@ func nugetCmd(c *cli.Context) error {
 		if len(filteredNugetArgs) > 1 {
 			nugetCmd.SetArgAndFlags(filteredNugetArgs[1:])
 		}
-		return commands.Exec(nugetCmd)
+		return execAndReportServer(c, nugetCmd)
 	}
 	// If config file not found, use nuget legacy command
 	return nugetLegacyCmd(c)
@@ -1533,7 +1562,7 @@ func nugetLegacyCmd(c *cli.Context) error {
 		SetUseNugetV2(c.Bool(cliutils.LegacyNugetV2)).
 		SetServerDetails(rtDetails)
 
-	return commands.Exec(nugetCmd)
+	return execAndReportServer(c, nugetCmd)
 }
 
 func getLegacyNugetArgsList(c *cli.Context) []string {
@@ -1592,7 +1621,7 @@ func dotnetCmd(c *cli.Context) error {
 	if len(filteredDotnetArgs) > 1 {
 		dotnetCmd.SetArgAndFlags(filteredDotnetArgs[1:])
 	}
-	return commands.Exec(dotnetCmd)
+	return execAndReportServer(c, dotnetCmd)
 }
 
 func getNugetAndDotnetConfigFields(configFilePath string) (rtDetails *coreConfig.ServerDetails, targetRepo string, useNugetV2 bool, err error) {
@@ -1706,13 +1735,13 @@ func npmPublishCmd(c *cli.Context) error {
 		}
 		npmCmd := npm.NewNpmPublishCommand()
 		npmCmd.SetConfigFilePath(configFilePath).SetArgs(args)
-		err = commands.Exec(npmCmd)
+		err = execAndReportServer(c, npmCmd)
 		if err != nil {
 			return err
 		}
 		if npmCmd.IsDetailedSummary() {
 			result := npmCmd.Result()
-			return cliutils.PrintDetailedSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), true, err)
+			return cliutils.PrintDetailedSummaryReport(c, result.SuccessCount(), result.FailCount(), result.Reader(), true, err)
 		}
 		return nil
 	}
@@ -1740,7 +1769,7 @@ func npmLegacyPublishCmd(c *cli.Context) error {
 	}
 	npmPublicCmd.SetBuildConfiguration(buildConfiguration).SetRepo(c.Args().Get(0)).SetNpmArgs(npmPublicArgs).SetServerDetails(rtDetails)
 
-	return commands.Exec(npmPublicCmd)
+	return execAndReportServer(c, npmPublicCmd)
 }
 
 func yarnCmd(c *cli.Context) error {
@@ -1758,7 +1787,7 @@ func yarnCmd(c *cli.Context) error {
 	}
 
 	yarnCmd := yarn.NewYarnCommand().SetConfigFilePath(configFilePath).SetArgs(c.Args())
-	return commands.Exec(yarnCmd)
+	return execAndReportServer(c, yarnCmd)
 }
 
 // This function checks whether the command received --help as a single option.
@@ -1873,7 +1902,7 @@ func goLegacyCmd(c *cli.Context) error {
 	}
 	goArg, err := coreutils.ParseArgs(strings.Split(c.Args().Get(0), " "))
 	if err != nil {
-		err = cliutils.PrintSummaryReport(0, 1, err)
+		err = cliutils.PrintSummaryReport(c, 0, 1, err)
 	}
 	targetRepo := c.Args().Get(1)
 	details, err := createArtifactoryDetailsByFlags(c, false)
@@ -1895,7 +1924,7 @@ func goLegacyCmd(c *cli.Context) error {
 	}
 	err = commands.Exec(goCmd)
 	if err != nil {
-		err = cliutils.PrintSummaryReport(0, 1, err)
+		err = cliutils.PrintSummaryReport(c, 0, 1, err)
 	}
 	return err
 }
@@ -1908,9 +1937,9 @@ func goPublishCmd(c *cli.Context, configFilePath string) error {
 	version := c.Args().Get(0)
 	goPublishCmd := golang.NewGoPublishCommand()
 	goPublishCmd.SetConfigFilePath(configFilePath).SetBuildConfiguration(buildConfiguration).SetVersion(version).SetDependencies(c.String("deps")).SetDetailedSummary(c.Bool("detailed-summary"))
-	err = commands.Exec(goPublishCmd)
+	err = execAndReportServer(c, goPublishCmd)
 	result := goPublishCmd.Result()
-	return cliutils.PrintDetailedSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), true, err)
+	return cliutils.PrintDetailedSummaryReport(c, result.SuccessCount(), result.FailCount(), result.Reader(), true, err)
 }
 
 func goLegacyPublishCmd(c *cli.Context) error {
@@ -1937,9 +1966,9 @@ func goLegacyPublishCmd(c *cli.Context) error {
 	}
 	goPublishCmd := golang.NewGoLegacyPublishCommand().SetPublishPackage(c.BoolT("self"))
 	goPublishCmd.SetBuildConfiguration(buildConfiguration).SetVersion(version).SetDependencies(c.String("deps")).SetTargetRepo(targetRepo).SetServerDetails(details)
-	err = commands.Exec(goPublishCmd)
+	err = execAndReportServer(c, goPublishCmd)
 	result := goPublishCmd.Result()
-	return cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	return cliutils.PrintSummaryReport(c, result.SuccessCount(), result.FailCount(), err)
 }
 
 func goNativeCmd(c *cli.Context, configFilePath string) error {
@@ -2018,6 +2047,15 @@ func pingCmd(c *cli.Context) error {
 	if c.NArg() > 0 {
 		return cliutils.PrintHelpAndReturnError("No arguments should be sent.", c)
 	}
+	// The response is printed as is, unless an output format or fields are requested.
+	var outputOptions *cliutils.OutputOptions
+	var err error
+	if c.IsSet("format") || c.IsSet("fields") {
+		outputOptions, err = cliutils.GetOutputOptions(c, cliutils.Table, pingResultFields, pingResultFields)
+		if err != nil {
+			return err
+		}
+	}
 	artDetails, err := createArtifactoryDetailsByFlags(c, false)
 	if err != nil {
 		return err
@@ -2029,9 +2067,20 @@ func pingCmd(c *cli.Context) error {
 	if err != nil {
 		return errors.New(err.Error() + "\n" + resString)
 	}
-	log.Output(resString)
+	if outputOptions == nil {
+		log.Output(resString)
+		return nil
+	}
+	return cliutils.PrintOutputRecord(outputOptions, &pingResult{Url: artDetails.ArtifactoryUrl, Response: string(pingCmd.Response())})
+}
 
-	return err
+// The fields of the ping result, which can be selected using the --fields option.
+var pingResultFields = []string{"url", "response"}
+
+// The result of the ping command, when rendered using the --format option.
+type pingResult struct {
+	Url      string `json:"url"`
+	Response string `json:"response"`
 }
 
 func prepareDownloadCommand(c *cli.Context) (*spec.SpecFiles, error) {
@@ -2060,7 +2109,7 @@ func prepareDownloadCommand(c *cli.Context) (*spec.SpecFiles, error) {
 }
 
 func downloadCmd(c *cli.Context) error {
-	downloadSpec, err := prepareDownloadCommand(c)
+	downloadSpec, downloadLock, err := prepareDownloadSpecAndLock(c)
 	if err != nil {
 		return err
 	}
@@ -2081,21 +2130,157 @@ func downloadCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	summaryFileOptions, err := cliutils.GetSummaryFileOptions(c, "rt download")
+	if err != nil {
+		return err
+	}
+	downloadCache, err := createDownloadCache(c)
+	if err != nil {
+		return err
+	}
+	resumableDownloader, err := createResumableDownloader(c, serverDetails, configuration, retries)
+	if err != nil {
+		return err
+	}
 	downloadCommand := generic.NewDownloadCommand()
-	downloadCommand.SetConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(downloadSpec).SetServerDetails(serverDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(c.Bool("detailed-summary")).SetRetries(retries)
+	// The downloaded files are read from the detailed summary, to add them to the download cache.
+	downloadCommand.SetConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(downloadSpec).SetServerDetails(serverDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(c.Bool("detailed-summary") || summaryFileOptions != nil || downloadCache != nil).SetRetries(retries)
 
 	if downloadCommand.ShouldPrompt() && !coreutils.AskYesNo("Sync-deletes may delete some files in your local file system. Are you sure you want to continue?\n"+
 		"You can avoid this confirmation message by adding --quiet to the command.", false) {
 		return nil
 	}
 
-	err = execWithProgress(downloadCommand)
+	traceSpecRepos(downloadSpec, false)
+	items, err := searchDownloadItems(c, downloadSpec, serverDetails, retries, downloadCache != nil || resumableDownloader != nil)
+	if err != nil {
+		return err
+	}
+	if downloadLock != nil {
+		if err = downloadLock.Verify(items); err != nil {
+			return err
+		}
+	}
+	prepareDownload(items, downloadCache, resumableDownloader)
+	transferStats, err := execWithProgress(downloadCommand)
 	result := downloadCommand.Result()
-	err = cliutils.PrintDetailedSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), false, err)
+	if downloadCache != nil {
+		if cacheErr := downloadCache.Update(result.Reader()); cacheErr != nil {
+			log.Warn("Failed updating the download cache: " + cacheErr.Error())
+		}
+	}
+	if err == nil && c.IsSet("write-lock") {
+		err = writeDownloadLock(c.String("write-lock"), items, !c.Bool("dry-run"), c.Bool("explode"))
+	}
+	if err == nil && downloadLock != nil && !c.Bool("dry-run") {
+		err = downloadLock.VerifyDownloaded(c.Bool("explode"))
+	}
+	err = printTransferSummaryReport(c, summaryFileOptions, result, transferStats, false, err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
+// Returns the download spec, and the lock file if the --lock option is set, in which case the spec is created from the lock file.
+func prepareDownloadSpecAndLock(c *cli.Context) (*spec.SpecFiles, *downloadlock.Lock, error) {
+	if !c.IsSet("lock") {
+		downloadSpec, err := prepareDownloadCommand(c)
+		if err != nil || !c.IsSet("write-lock") {
+			return downloadSpec, nil, err
+		}
+		for _, file := range downloadSpec.Files {
+			if file.Build != "" || file.Bundle != "" {
+				return nil, nil, cliutils.PrintHelpAndReturnError("The --write-lock option can't be used to download the artifacts of builds or bundles.", c)
+			}
+		}
+		return downloadSpec, nil, nil
+	}
+	if c.IsSet("write-lock") {
+		return nil, nil, cliutils.PrintHelpAndReturnError("The --lock and --write-lock options can't be used together.", c)
+	}
+	if c.NArg() > 0 || c.IsSet("spec") || c.IsSet("build") || c.IsSet("bundle") {
+		return nil, nil, cliutils.PrintHelpAndReturnError("No arguments, spec, build or bundle should be sent when the lock option is used, since the artifacts are read from the lock file.", c)
+	}
+	downloadLock, err := downloadlock.Load(c.String("lock"))
+	if err != nil {
+		return nil, nil, err
+	}
+	downloadSpec, err := downloadLock.CreateSpec(c.String("explode"), c.Bool("validate-symlinks"))
+	return downloadSpec, downloadLock, err
+}
+
+// Searches the artifacts to download in advance. The lock options require the search, so its failure fails the command.
+// Otherwise, the artifacts are searched only to prepare the download, and a failure is reported as a warning.
+func searchDownloadItems(c *cli.Context, downloadSpec *spec.SpecFiles, serverDetails *coreConfig.ServerDetails, retries int, prepare bool) (map[string]*downloaditems.Item, error) {
+	locked := c.IsSet("lock") || c.IsSet("write-lock")
+	if !locked && !prepare {
+		return nil, nil
+	}
+	items, err := downloaditems.SearchSpec(downloadSpec, serverDetails, retries)
+	if err != nil && !locked {
+		log.Warn("Failed searching the artifacts to download in advance: " + err.Error())
+		return nil, nil
+	}
+	return items, err
+}
+
+// Copies the cached artifacts from the download cache, and if the --resumable option is set, downloads the artifacts which are
+// downloaded in concurrent ranges, resuming their interrupted downloads, before the download command runs. The download command then finds them locally and doesn't download them.
+// Both only save downloads, so their failures are reported as warnings, and the download command downloads the artifacts.
+func prepareDownload(items map[string]*downloaditems.Item, downloadCache *downloadcache.DownloadCache, resumableDownloader *resumabledownload.Downloader) {
+	if items == nil {
+		return
+	}
+	if downloadCache != nil {
+		if err := downloadCache.Prepare(items); err != nil {
+			log.Warn("Failed using the download cache: " + err.Error())
+		}
+	}
+	if resumableDownloader != nil {
+		resumableDownloader.Download(items)
+	}
+}
+
+// Writes the lock file of the searched artifacts. The checksums are recorded from the search, so if verifyDownloaded is set,
+// the downloaded files are verified against the lock first, and the lock file isn't written if they were changed since the search.
+func writeDownloadLock(lockPath string, items map[string]*downloaditems.Item, verifyDownloaded, explode bool) error {
+	downloadLock, err := downloadlock.NewLock(items)
+	if err != nil {
+		return err
+	}
+	if verifyDownloaded {
+		if err = downloadLock.VerifyDownloaded(explode); err != nil {
+			return err
+		}
+	}
+	if err = downloadLock.Save(lockPath); err != nil {
+		return err
+	}
+	log.Info("Recorded", len(downloadLock.Artifacts), "artifacts in the lock file", lockPath)
+	return nil
+}
+
+// Returns nil unless the --resumable option is set, in dry runs, or if the artifacts aren't downloaded in concurrent ranges.
+func createResumableDownloader(c *cli.Context, serverDetails *coreConfig.ServerDetails, configuration *utils.DownloadConfiguration, retries int) (*resumabledownload.Downloader, error) {
+	if !c.Bool("resumable") || c.Bool("dry-run") {
+		return nil, nil
+	}
+	return resumabledownload.New(serverDetails, configuration, retries)
+}
+
+// Returns the download cache, if its directory is set by the --cache-dir option or the JFROG_CLI_DOWNLOAD_CACHE environment variable.
+// The download cache isn't used in dry runs.
+func createDownloadCache(c *cli.Context) (*downloadcache.DownloadCache, error) {
+	dir := cacheutils.GetDir(c.String("cache-dir"))
+	if dir == "" || c.Bool("dry-run") {
+		return nil, nil
+	}
+	cache, err := cacheutils.New(dir)
+	if err != nil {
+		return nil, err
+	}
+	return downloadcache.New(cache), nil
+}
+
 func uploadCmd(c *cli.Context) error {
 	if c.NArg() > 0 && c.IsSet("spec") {
 		return cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
@@ -2131,20 +2316,127 @@ func uploadCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	summaryFileOptions, err := cliutils.GetSummaryFileOptions(c, "rt upload")
+	if err != nil {
+		return err
+	}
 	uploadCmd := generic.NewUploadCommand()
 	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
 	if err != nil {
 		return err
 	}
-	uploadCmd.SetUploadConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(uploadSpec).SetServerDetails(rtDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(c.Bool("detailed-summary")).SetRetries(retries)
+	stateTracker, err := createUploadStateTracker(c, rtDetails, buildConfiguration, retries)
+	if err != nil {
+		return err
+	}
+	if stateTracker != nil {
+		if uploadSpec, err = stateTracker.FilterSpec(uploadSpec, rtDetails, retries); err != nil {
+			return err
+		}
+		if uploadSpec == nil {
+			log.Info("All the files are unchanged. Nothing to upload.")
+			if !c.Bool("dry-run") {
+				err = stateTracker.Save()
+			}
+			err = printTransferSummaryReport(c, summaryFileOptions, uploadCmd.Result(), nil, true, err)
+			return cliutils.GetCliError(err, 0, 0, isFailNoOp(c))
+		}
+	}
+	// The uploaded files are read from the detailed summary, to record them in the state file.
+	uploadCmd.SetUploadConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(uploadSpec).SetServerDetails(rtDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(c.Bool("detailed-summary") || summaryFileOptions != nil || stateTracker != nil).SetRetries(retries)
 
 	if uploadCmd.ShouldPrompt() && !coreutils.AskYesNo("Sync-deletes may delete some artifacts in Artifactory. Are you sure you want to continue?\n"+
 		"You can avoid this confirmation message by adding --quiet to the command.", false) {
 		return nil
 	}
-	err = execWithProgress(uploadCmd)
+	traceSpecRepos(uploadSpec, true)
+	transferStats, err := execWithProgress(uploadCmd)
 	result := uploadCmd.Result()
-	err = cliutils.PrintDetailedSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), true, err)
+	if stateTracker != nil && !c.Bool("dry-run") {
+		err = updateUploadState(stateTracker, result, err)
+	}
+	err = printTransferSummaryReport(c, summaryFileOptions, result, transferStats, true, err)
+
+	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
+}
+
+// Returns the tracker of the upload state file, if the --state-file option is set.
+func createUploadStateTracker(c *cli.Context, rtDetails *coreConfig.ServerDetails, buildConfiguration *utils.BuildConfiguration, retries int) (*uploadstate.Tracker, error) {
+	if !c.IsSet("state-file") {
+		if c.IsSet("verify-state") {
+			return nil, errors.New("the --verify-state option can be used only with the --state-file option")
+		}
+		return nil, nil
+	}
+	// The skipped files wouldn't be synced or included in the build-info.
+	if c.IsSet("sync-deletes") {
+		return nil, errors.New("the --state-file option can't be used with the --sync-deletes option")
+	}
+	if buildConfiguration.BuildName != "" {
+		return nil, errors.New("the --state-file option can't be used with build-info collection")
+	}
+	var verifyInterval *time.Duration
+	if c.IsSet("verify-state") {
+		interval, err := time.ParseDuration(c.String("verify-state"))
+		if err != nil || interval < 0 {
+			return nil, errors.New("the --verify-state option should be a duration, such as 24h")
+		}
+		verifyInterval = &interval
+	}
+	return uploadstate.NewTracker(c.String("state-file"), rtDetails.ArtifactoryUrl, verifyInterval)
+}
+
+// Records the uploaded files in the upload state file. The files which were uploaded before the upload failed are recorded too.
+func updateUploadState(stateTracker *uploadstate.Tracker, result *commandsutils.Result, originalErr error) error {
+	err := stateTracker.Update(result.Reader())
+	if err == nil {
+		err = stateTracker.Save()
+	}
+	if err == nil {
+		return originalErr
+	}
+	if originalErr == nil {
+		return err
+	}
+	log.Error("Failed updating the upload state file:", err)
+	return originalErr
+}
+
+// Writes the summary file, if requested, and prints the summary report.
+// The affected files are collected also for the summary file, but are printed only if the --detailed-summary option is set.
+func printTransferSummaryReport(c *cli.Context, summaryFileOptions *cliutils.SummaryFileOptions, result *commandsutils.Result, transferStats *summary.TransferStats, printExtendedDetails bool, originalErr error) error {
+	err := cliutils.WriteSummaryFile(summaryFileOptions, result.SuccessCount(), result.FailCount(), result.Reader(), originalErr)
+	reader := result.Reader()
+	if reader != nil && !c.Bool("detailed-summary") {
+		reader.Close()
+		reader = nil
+	}
+	return cliutils.PrintTransferSummaryReport(c, result.SuccessCount(), result.FailCount(), transferStats, reader, printExtendedDetails, err)
+}
+
+type commandWithResult interface {
+	commands.Command
+	Result() *commandsutils.Result
+}
+
+// Runs a command which records the outcome of each item, writes the summary file, if requested,
+// and prints the summary report. The items are printed only if the --detailed-summary option is set.
+// If withProgress is set, the command should implement CommandWithProgress, and its progress is displayed if possible.
+func execWithItemsSummary(c *cli.Context, cmd commandWithResult, summaryFileOptions *cliutils.SummaryFileOptions, withProgress bool) error {
+	var err error
+	if withProgress {
+		err = execWithItemsProgress(cmd.(CommandWithProgress))
+	} else {
+		err = commands.Exec(cmd)
+	}
+	result := cmd.Result()
+	err = cliutils.WriteItemsSummaryFile(summaryFileOptions, result.SuccessCount(), result.FailCount(), result.Reader(), err)
+	reader := result.Reader()
+	if reader != nil && !c.Bool("detailed-summary") {
+		reader.Close()
+		reader = nil
+	}
+	err = cliutils.PrintItemsSummaryReport(c, result.SuccessCount(), result.FailCount(), reader, err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
@@ -2154,16 +2446,63 @@ type CommandWithProgress interface {
 	SetProgress(ioUtils.ProgressMgr)
 }
 
-func execWithProgress(cmd CommandWithProgress) error {
+// Runs a command which transfers files, while displaying its progress if possible.
+// Returns the transfer statistics, to be included in the summary report.
+func execWithProgress(cmd CommandWithProgress) (*summary.TransferStats, error) {
 	// Init progress bar.
 	progressBar, logFile, err := progressbar.InitProgressBarIfPossible()
+	if err != nil {
+		return nil, err
+	}
+	if progressBar == nil {
+		// Collect the transfer statistics for the summary, although the progress isn't displayed.
+		progressBar = progressbar.NewTransferStatsCollector()
+	}
+	// Record a tracing span for each file transfer, if tracing is enabled.
+	cmd.SetProgress(tracing.WrapProgressMgr(progressBar))
+	defer logUtils.CloseLogFile(logFile)
+	err = commands.Exec(cmd)
+	progressBar.Quit()
+	var transferStats *summary.TransferStats
+	if statsCollector, ok := progressBar.(progressbar.TransferStatsCollector); ok {
+		transferStats = statsCollector.GetTransferStats()
+		if transferStats != nil {
+			tracing.SetCommandAttribute("jfrog.bytes_total", transferStats.TotalBytes)
+		}
+	}
+	return transferStats, err
+}
+
+// Records the repositories of the file specs in the command span, if tracing is enabled.
+// The repository of an upload is the first part of its target, and of other commands, the first part of their pattern.
+func traceSpecRepos(fileSpec *spec.SpecFiles, upload bool) {
+	if !tracing.Enabled() {
+		return
+	}
+	var repos []string
+	for _, file := range fileSpec.Files {
+		path := file.Pattern
+		if upload {
+			path = file.Target
+		}
+		if repo := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]; repo != "" {
+			repos = append(repos, repo)
+		}
+	}
+	tracing.SetCommandAttribute("jfrog.repo", strings.Join(repos, ","))
+}
+
+// Runs a command which acts on existing artifacts, such as move, copy and delete, while displaying its progress if possible.
+// Unlike execWithProgress, the transfer statistics aren't collected, since these commands don't transfer bytes.
+func execWithItemsProgress(cmd CommandWithProgress) error {
+	progressMgr, logFile, err := progressbar.InitProgressBarIfPossible()
 	if err != nil {
 		return err
 	}
-	if progressBar != nil {
-		cmd.SetProgress(progressBar)
-		defer logUtils.CloseLogFile(logFile)
-		defer progressBar.Quit()
+	defer logUtils.CloseLogFile(logFile)
+	if progressMgr != nil {
+		cmd.SetProgress(progressMgr)
+		defer progressMgr.Quit()
 	}
 	return commands.Exec(cmd)
 }
@@ -2198,7 +2537,6 @@ func moveCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	moveCmd := generic.NewMoveCommand()
 	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
 	if err != nil {
 		return err
@@ -2211,10 +2549,20 @@ func moveCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	summaryFileOptions, err := cliutils.GetSummaryFileOptions(c, "rt move")
+	if err != nil {
+		return err
+	}
+	if c.Bool("detailed-summary") || summaryFileOptions != nil {
+		detailedMoveCmd := detailedsummary.NewMoveCopyCommand(services.MOVE).SetThreads(threads)
+		detailedMoveCmd.SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(moveSpec).SetRetries(retries)
+		return execWithItemsSummary(c, detailedMoveCmd, summaryFileOptions, true)
+	}
+	moveCmd := generic.NewMoveCommand()
 	moveCmd.SetThreads(threads).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(moveSpec).SetRetries(retries)
 	err = commands.Exec(moveCmd)
 	result := moveCmd.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	err = cliutils.PrintSummaryReport(c, result.SuccessCount(), result.FailCount(), err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
@@ -2225,7 +2573,6 @@ func copyCmd(c *cli.Context) error {
 		return err
 	}
 
-	copyCommand := generic.NewCopyCommand()
 	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
 	if err != nil {
 		return err
@@ -2238,10 +2585,20 @@ func copyCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	summaryFileOptions, err := cliutils.GetSummaryFileOptions(c, "rt copy")
+	if err != nil {
+		return err
+	}
+	if c.Bool("detailed-summary") || summaryFileOptions != nil {
+		detailedCopyCmd := detailedsummary.NewMoveCopyCommand(services.COPY).SetThreads(threads)
+		detailedCopyCmd.SetSpec(copySpec).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetRetries(retries)
+		return execWithItemsSummary(c, detailedCopyCmd, summaryFileOptions, true)
+	}
+	copyCommand := generic.NewCopyCommand()
 	copyCommand.SetThreads(threads).SetSpec(copySpec).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetRetries(retries)
 	err = commands.Exec(copyCommand)
 	result := copyCommand.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	err = cliutils.PrintSummaryReport(c, result.SuccessCount(), result.FailCount(), err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
@@ -2277,7 +2634,6 @@ func deleteCmd(c *cli.Context) error {
 		return err
 	}
 
-	deleteCommand := generic.NewDeleteCommand()
 	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
 	if err != nil {
 		return err
@@ -2291,10 +2647,22 @@ func deleteCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	deleteCommand.SetThreads(threads).SetQuiet(cliutils.GetQuietValue(c)).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(deleteSpec).SetRetries(retries)
+	summaryFileOptions, err := cliutils.GetSummaryFileOptions(c, "rt delete")
+	if err != nil {
+		return err
+	}
+	quiet := cliutils.GetQuietValue(c)
+	if c.Bool("detailed-summary") || summaryFileOptions != nil {
+		detailedDeleteCmd := detailedsummary.NewDeleteCommand()
+		detailedDeleteCmd.SetThreads(threads).SetQuiet(quiet).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(deleteSpec).SetRetries(retries)
+		// The progress is displayed only if quiet, since it would hide the deletion confirmation.
+		return execWithItemsSummary(c, detailedDeleteCmd, summaryFileOptions, quiet)
+	}
+	deleteCommand := generic.NewDeleteCommand()
+	deleteCommand.SetThreads(threads).SetQuiet(quiet).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(deleteSpec).SetRetries(retries)
 	err = commands.Exec(deleteCommand)
 	result := deleteCommand.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	err = cliutils.PrintSummaryReport(c, result.SuccessCount(), result.FailCount(), err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
@@ -2329,6 +2697,10 @@ func searchCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	outputOptions, err := cliutils.GetOutputOptions(c, cliutils.Json, searchResultFields, searchResultTableFields)
+	if err != nil {
+		return err
+	}
 	artDetails, err := createArtifactoryDetailsByFlags(c, false)
 	if err != nil {
 		return err
@@ -2337,9 +2709,9 @@ func searchCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	searchCmd := generic.NewSearchCommand()
+	searchCmd := detailedsummary.NewSearchCommand()
 	searchCmd.SetServerDetails(artDetails).SetSpec(searchSpec).SetRetries(retries)
-	err = commands.Exec(searchCmd)
+	err = execWithItemsProgress(searchCmd)
 	if err != nil {
 		return err
 	}
@@ -2354,12 +2726,96 @@ func searchCmd(c *cli.Context) error {
 		return err
 	}
 	if !c.Bool("count") {
-		return utils.PrintSearchResults(reader)
+		return printSearchResults(reader, outputOptions)
 	}
 	log.Output(length)
 	return nil
 }
 
+var (
+	// The fields of the search results, which can be selected using the --fields option.
+	searchResultFields = []string{"path", "type", "size", "created", "modified", "sha1", "md5", "props"}
+	// The default columns of the search results, in the table and csv formats.
+	searchResultTableFields = []string{"path", "size", "modified", "sha1"}
+)
+
+func printSearchResults(reader *content.ContentReader, outputOptions *cliutils.OutputOptions) error {
+	writer := cliutils.NewOutputWriter(outputOptions)
+	for searchResult := new(utils.SearchResult); reader.NextRecord(searchResult) == nil; searchResult = new(utils.SearchResult) {
+		if err := writer.Write(searchResult); err != nil {
+			return err
+		}
+	}
+	if err := reader.GetError(); err != nil {
+		return err
+	}
+	reader.Reset()
+	return writer.Close()
+}
+
+func diffCmd(c *cli.Context) error {
+	if c.NArg() > 0 && c.IsSet("spec") {
+		return cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
+	}
+	if !(c.NArg() == 2 || (c.NArg() == 0 && c.IsSet("spec"))) {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	remoteSource := c.Bool("remote-source") || c.IsSet("source-server-id")
+	var diffSpec *spec.SpecFiles
+	var err error
+	if c.IsSet("spec") {
+		diffSpec, err = getSpec(c, remoteSource)
+	} else {
+		diffSpec, err = createDefaultDiffSpec(c, remoteSource)
+	}
+	if err != nil {
+		return err
+	}
+	if err = spec.ValidateSpec(diffSpec.Files, true, remoteSource, !remoteSource); err != nil {
+		return err
+	}
+	if !remoteSource {
+		fixWinPathsForFileSystemSourcedCmds(diffSpec, c)
+	}
+	outputOptions, err := cliutils.GetOutputOptions(c, cliutils.Table, diffResultFields, diffResultTableFields)
+	if err != nil {
+		return err
+	}
+	artDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	diffCmd := diff.NewDiffCommand().SetServerDetails(artDetails).SetSpec(diffSpec).SetRemoteSource(remoteSource).SetRetries(retries)
+	if c.IsSet("source-server-id") {
+		sourceDetails, err := coreConfig.GetSpecificConfig(c.String("source-server-id"), true, false)
+		if err != nil {
+			return err
+		}
+		diffCmd.SetSourceServerDetails(sourceDetails)
+	}
+	if err = commands.Exec(diffCmd); err != nil {
+		return err
+	}
+	writer := cliutils.NewOutputWriter(outputOptions)
+	for _, record := range diffCmd.Result() {
+		if err = writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+var (
+	// The fields of the diff results, which can be selected using the --fields option.
+	diffResultFields = []string{"path", "status", "source", "sourceSha256", "target", "targetSha256"}
+	// The default columns of the diff results, in the table and csv formats.
+	diffResultTableFields = []string{"status", "path"}
+)
+
 func preparePropsCmd(c *cli.Context) (*generic.PropsCommand, error) {
 	if c.NArg() > 1 && c.IsSet("spec") {
 		return nil, cliutils.PrintHelpAndReturnError("Only the 'artifact properties' argument should be sent when the spec option is used.", c)
@@ -2415,11 +2871,16 @@ func setPropsCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.Bool("detailed-summary") {
+		detailedPropsCmd := detailedsummary.NewSetPropsCommand(*cmd)
+		detailedPropsCmd.SetRetries(retries)
+		return execWithItemsSummary(c, detailedPropsCmd, nil, false)
+	}
 	propsCmd := generic.NewSetPropsCommand().SetPropsCommand(*cmd)
 	propsCmd.SetRetries(retries)
 	err = commands.Exec(propsCmd)
 	result := propsCmd.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	err = cliutils.PrintSummaryReport(c, result.SuccessCount(), result.FailCount(), err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
@@ -2433,11 +2894,16 @@ func deletePropsCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.Bool("detailed-summary") {
+		detailedPropsCmd := detailedsummary.NewDeletePropsCommand(*cmd)
+		detailedPropsCmd.SetRetries(retries)
+		return execWithItemsSummary(c, detailedPropsCmd, nil, false)
+	}
 	propsCmd := generic.NewDeletePropsCommand().DeletePropsCommand(*cmd)
 	propsCmd.SetRetries(retries)
 	err = commands.Exec(propsCmd)
 	result := propsCmd.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	err = cliutils.PrintSummaryReport(c, result.SuccessCount(), result.FailCount(), err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
@@ -2455,14 +2921,34 @@ func buildPublishCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration).SetDetailedSummary(c.Bool("detailed-summary"))
+	summaryFileOptions, err := cliutils.GetSummaryFileOptions(c, "rt build-publish")
+	if err != nil {
+		return err
+	}
+	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration).SetDetailedSummary(c.Bool("detailed-summary") || summaryFileOptions != nil)
 
+	span := tracing.StartSpan("build-info publish")
+	span.SetAttribute("jfrog.build.name", buildConfiguration.BuildName)
+	span.SetAttribute("jfrog.build.number", buildConfiguration.BuildNumber)
 	err = commands.Exec(buildPublishCmd)
-	if buildPublishCmd.IsDetailedSummary() {
-		if summary := buildPublishCmd.GetSummary(); summary != nil {
-			return cliutils.PrintBuildInfoSummaryReport(summary.IsSucceeded(), summary.GetSha256(), err)
+	span.SetError(err)
+	span.End()
+	if err == nil {
+		// Record the build name, to be suggested when completing the --build-name option.
+		if recordErr := dynamic.RecordBuildName(buildConfiguration.BuildName); recordErr != nil {
+			log.Debug("Failed recording the build name for completion: " + recordErr.Error())
 		}
 	}
+	succeeded, sha256 := err == nil, ""
+	summary := buildPublishCmd.GetSummary()
+	if summary != nil {
+		succeeded, sha256 = summary.IsSucceeded(), summary.GetSha256()
+	}
+	err = cliutils.WriteBuildInfoSummaryFile(summaryFileOptions, buildConfiguration.BuildName, buildConfiguration.BuildNumber, succeeded, sha256, err)
+	if c.Bool("detailed-summary") && summary != nil {
+		return cliutils.PrintBuildInfoSummaryReport(c, succeeded, sha256, err)
+	}
+	cliutils.SetBuildInfoCommandResult(c, succeeded, sha256, err)
 	return err
 }
 
@@ -2522,7 +3008,7 @@ func buildAddDependenciesCmd(c *cli.Context) error {
 	buildAddDependenciesCmd := buildinfo.NewBuildAddDependenciesCommand().SetDryRun(c.Bool("dry-run")).SetBuildConfiguration(buildConfiguration).SetDependenciesSpec(dependenciesSpec).SetServerDetails(rtDetails)
 	err = commands.Exec(buildAddDependenciesCmd)
 	result := buildAddDependenciesCmd.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	err = cliutils.PrintSummaryReport(c, result.SuccessCount(), result.FailCount(), err)
 	if err != nil {
 		return err
 	}
@@ -2538,7 +3024,7 @@ func buildCollectEnvCmd(c *cli.Context) error {
 	if err := validateBuildConfiguration(c, buildConfiguration); err != nil {
 		return err
 	}
-	buildCollectEnvCmd := buildinfo.NewBuildCollectEnvCommand().SetBuildConfiguration(buildConfiguration)
+	buildCollectEnvCmd := buildenv.NewBuildCollectEnvCommand().SetBuildConfiguration(buildConfiguration)
 
 	return commands.Exec(buildCollectEnvCmd)
 }
@@ -2692,7 +3178,7 @@ func releaseBundleCreateCmd(c *cli.Context) error {
 	commands.Exec(releaseBundleCreateCmd)
 	if releaseBundleCreateCmd.IsDetailedSummary() {
 		if summary := releaseBundleCreateCmd.GetSummary(); summary != nil {
-			return cliutils.PrintBuildInfoSummaryReport(summary.IsSucceeded(), summary.GetSha256(), err)
+			return cliutils.PrintBuildInfoSummaryReport(c, summary.IsSucceeded(), summary.GetSha256(), err)
 		}
 	}
 	return err
@@ -2734,7 +3220,7 @@ func releaseBundleUpdateCmd(c *cli.Context) error {
 	err = commands.Exec(releaseBundleUpdateCmd)
 	if releaseBundleUpdateCmd.IsDetailedSummary() {
 		if summary := releaseBundleUpdateCmd.GetSummary(); summary != nil {
-			return cliutils.PrintBuildInfoSummaryReport(summary.IsSucceeded(), summary.GetSha256(), err)
+			return cliutils.PrintBuildInfoSummaryReport(c, summary.IsSucceeded(), summary.GetSha256(), err)
 		}
 	}
 	return err
@@ -2757,7 +3243,7 @@ func releaseBundleSignCmd(c *cli.Context) error {
 	err = commands.Exec(releaseBundleSignCmd)
 	if releaseBundleSignCmd.IsDetailedSummary() {
 		if summary := releaseBundleSignCmd.GetSummary(); summary != nil {
-			return cliutils.PrintBuildInfoSummaryReport(summary.IsSucceeded(), summary.GetSha256(), err)
+			return cliutils.PrintBuildInfoSummaryReport(c, summary.IsSucceeded(), summary.GetSha256(), err)
 		}
 	}
 	return err
@@ -2848,6 +3334,16 @@ func gitLfsCleanCmd(c *cli.Context) error {
 	return commands.Exec(gitLfsCmd)
 }
 
+// Runs the command, and reports the server it ran against, to be recorded in the audit log.
+// Used by the commands which resolve their server from a config file or from their arguments, rather than from the command's flags.
+func execAndReportServer(c *cli.Context, command commands.Command) error {
+	err := commands.Exec(command)
+	if serverDetails, detailsErr := command.ServerDetails(); detailsErr == nil && serverDetails != nil {
+		cliutils.SetCommandServerDetails(c, serverDetails)
+	}
+	return err
+}
+
 func curlCmd(c *cli.Context) error {
 	if c.NArg() < 1 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
@@ -2856,7 +3352,7 @@ func curlCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	return commands.Exec(rtCurlCommand)
+	return execAndReportServer(c, rtCurlCommand)
 }
 
 func newRtCurlCommand(c *cli.Context) (*curl.RtCurlCommand, error) {
@@ -3435,6 +3931,24 @@ func createDefaultSearchSpec(c *cli.Context) (*spec.SpecFiles, error) {
 		BuildSpec(), nil
 }
 
+// Creates the spec of the diff command. The source is a local pattern, resolved like the upload command's source,
+// or a repository path, resolved like the search command's pattern.
+func createDefaultDiffSpec(c *cli.Context, remoteSource bool) (*spec.SpecFiles, error) {
+	builder := spec.NewBuilder().
+		Pattern(c.Args().Get(0)).
+		Target(strings.TrimPrefix(c.Args().Get(1), "/")).
+		Recursive(c.BoolT("recursive")).
+		Exclusions(cliutils.GetStringsArrFlagValue(c, "exclusions"))
+	if remoteSource {
+		return builder.Pattern(strings.TrimPrefix(c.Args().Get(0), "/")).BuildSpec(), nil
+	}
+	return builder.
+		Flat(c.BoolT("flat")).
+		Regexp(c.Bool("regexp")).
+		Ant(c.Bool("ant")).
+		BuildSpec(), nil
+}
+
 func createDefaultPropertiesSpec(c *cli.Context) (*spec.SpecFiles, error) {
 	offset, limit, err := getOffsetAndLimitValues(c)
 	if err != nil {
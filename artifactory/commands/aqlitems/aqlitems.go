@@ -0,0 +1,183 @@
+// Package aqlitems searches artifacts using AQL queries which include their sha256 checksums,
+// which the search results of jfrog-client-go don't include.
+// The results are streamed to temporary files and read item by item, so that large results aren't held in memory.
+package aqlitems
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli/utils/tracing"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	rtutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// The number of artifacts searched by their paths in a single AQL query.
+const pathsChunkSize = 100
+
+// The fields included in the results of the queries.
+const includedFields = `"repo","path","name","type","size","actual_md5","actual_sha1","sha256"`
+
+// An artifact in the results of the AQL queries.
+type Item struct {
+	rtutils.ResultItem
+	Sha256 string `json:"sha256"`
+}
+
+// Searches the artifacts matching the search params, the same way the search and download commands search them.
+// Returns a reader of Item records, which should be closed.
+func SearchSpec(servicesManager artifactory.ArtifactoryServicesManager, params *rtutils.ArtifactoryCommonParams) (*content.ContentReader, error) {
+	aqlBody := params.Aql.ItemsFind
+	if params.GetSpecType() != rtutils.AQL {
+		var err error
+		if aqlBody, err = rtutils.CreateAqlBodyForSpecWithPattern(params); err != nil {
+			return nil, err
+		}
+	}
+	span := tracing.StartSpan("AQL search")
+	span.SetAttribute("jfrog.pattern", params.Pattern)
+	defer span.End()
+	filePath, err := execAql(servicesManager, createSpecQuery(aqlBody, params))
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	return content.NewContentReader(filePath, content.DefaultKey), nil
+}
+
+// Searches the artifacts by their paths, such as 'repo/dir/a.zip'. The artifacts which don't exist are omitted.
+// Returns a reader of Item records, which should be closed.
+func SearchPaths(servicesManager artifactory.ArtifactoryServicesManager, itemPaths []string) (*content.ContentReader, error) {
+	var filePaths []string
+	for start := 0; start < len(itemPaths); start += pathsChunkSize {
+		end := start + pathsChunkSize
+		if end > len(itemPaths) {
+			end = len(itemPaths)
+		}
+		filePath, err := searchPathsChunk(servicesManager, itemPaths[start:end])
+		if err != nil {
+			content.NewMultiSourceContentReader(filePaths, content.DefaultKey).Close()
+			return nil, err
+		}
+		filePaths = append(filePaths, filePath)
+	}
+	return content.NewMultiSourceContentReader(filePaths, content.DefaultKey), nil
+}
+
+func searchPathsChunk(servicesManager artifactory.ArtifactoryServicesManager, itemPaths []string) (string, error) {
+	query, err := createPathsQuery(itemPaths)
+	if err != nil {
+		return "", err
+	}
+	span := tracing.StartSpan("AQL search")
+	span.SetAttribute("jfrog.items", len(itemPaths))
+	defer span.End()
+	filePath, err := execAql(servicesManager, query)
+	span.SetError(err)
+	return filePath, err
+}
+
+// Returns the sha256 checksums of the existing artifacts, by their paths, such as 'repo/dir/a.zip'.
+func SearchChecksums(servicesManager artifactory.ArtifactoryServicesManager, itemPaths []string) (map[string]string, error) {
+	reader, err := SearchPaths(servicesManager, itemPaths)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	checksums := make(map[string]string)
+	for item := new(Item); reader.NextRecord(item) == nil; item = new(Item) {
+		checksums[item.GetItemRelativePath()] = item.Sha256
+	}
+	return checksums, reader.GetError()
+}
+
+// Runs the AQL query, and streams its results to a temporary file. Returns the path of the file.
+func execAql(servicesManager artifactory.ArtifactoryServicesManager, query string) (string, error) {
+	body, err := servicesManager.Aql(query)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	file, err := fileutils.CreateTempFile()
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return "", errorutils.CheckError(err)
+	}
+	return file.Name(), nil
+}
+
+// Returns an AQL query which finds the artifacts the same way the search and download commands do, and includes their sha256 checksums.
+func createSpecQuery(aqlBody string, params *rtutils.ArtifactoryCommonParams) string {
+	query := "items.find(" + aqlBody + ").include(" + includedFields + ")"
+	if len(params.SortBy) > 0 {
+		sortOrder := params.SortOrder
+		if sortOrder == "" {
+			sortOrder = "asc"
+		}
+		query += fmt.Sprintf(`.sort({"$%s":["%s"]})`, sortOrder, strings.Join(params.SortBy, `","`))
+	}
+	if params.Offset > 0 {
+		query += ".offset(" + strconv.Itoa(params.Offset) + ")"
+	}
+	if params.Limit > 0 {
+		query += ".limit(" + strconv.Itoa(params.Limit) + ")"
+	}
+	if params.Transitive {
+		query += ".transitive()"
+	}
+	return query
+}
+
+// Returns an AQL query which finds the artifacts by their exact paths, such as 'repo/dir/a.zip'.
+func createPathsQuery(itemPaths []string) (string, error) {
+	var criteria []map[string]string
+	for _, itemPath := range itemPaths {
+		criteria = append(criteria, createPathCriteria(itemPath))
+	}
+	body, err := json.Marshal(map[string]interface{}{"type": "file", "$or": criteria})
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return "items.find(" + string(body) + ").include(" + includedFields + ")", nil
+}
+
+// Returns the body of an AQL items.find query, which finds the file by its exact path, such as 'repo/dir/a.zip'.
+// Unlike a pattern, the path's '*' and '?' characters aren't taken as wildcards.
+func CreatePathAqlBody(itemPath string) (string, error) {
+	criteria := createPathCriteria(itemPath)
+	criteria["type"] = "file"
+	body, err := json.Marshal(criteria)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return string(body), nil
+}
+
+func createPathCriteria(itemPath string) map[string]string {
+	itemPath = strings.TrimPrefix(itemPath, "/")
+	repo, repoPath := itemPath, ""
+	if i := strings.Index(itemPath, "/"); i >= 0 {
+		repo, repoPath = itemPath[:i], itemPath[i+1:]
+	}
+	dir, name := path.Split(repoPath)
+	dir = strings.TrimSuffix(dir, "/")
+	if dir == "" {
+		dir = "."
+	}
+	return map[string]string{"repo": repo, "path": dir, "name": name}
+}
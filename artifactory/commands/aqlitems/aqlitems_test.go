@@ -0,0 +1,61 @@
+package aqlitems
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	rtutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSpecQuery(t *testing.T) {
+	body := `{"repo":"repo","path":{"$match":"*"},"name":{"$match":"*.zip"}}`
+	assert.Equal(t, `items.find(`+body+`).include(`+includedFields+`)`, createSpecQuery(body, &rtutils.ArtifactoryCommonParams{}))
+	params := &rtutils.ArtifactoryCommonParams{SortBy: []string{"created", "name"}, SortOrder: "desc", Offset: 2, Limit: 3}
+	assert.Equal(t, `items.find(`+body+`).include(`+includedFields+`).sort({"$desc":["created","name"]}).offset(2).limit(3)`,
+		createSpecQuery(body, params))
+}
+
+func TestCreatePathsQuery(t *testing.T) {
+	query, err := createPathsQuery([]string{"repo/dir/a.zip", "/repo/b.zip"})
+	assert.NoError(t, err)
+	assert.Equal(t, `items.find({"$or":[{"name":"a.zip","path":"dir","repo":"repo"},{"name":"b.zip","path":".","repo":"repo"}],"type":"file"}).include(`+includedFields+`)`, query)
+}
+
+func TestCreatePathAqlBody(t *testing.T) {
+	body, err := CreatePathAqlBody("repo/dir/a*(1).zip")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"a*(1).zip","path":"dir","repo":"repo","type":"file"}`, body)
+}
+
+func TestSearchChecksums(t *testing.T) {
+	log.SetLogger(log.NewLogger(log.INFO, nil))
+	queries := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries++
+		_, _ = w.Write([]byte(`{"results":[{"repo":"repo","path":"dir","name":"a.zip","type":"file","sha256":"a-sha256"}],"range":{"total":1}}`))
+	}))
+	defer server.Close()
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, false)
+	if !assert.NoError(t, err) {
+		return
+	}
+	checksums, err := SearchChecksums(servicesManager, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, checksums)
+	assert.Zero(t, queries)
+
+	// The paths are searched in chunks.
+	itemPaths := make([]string, pathsChunkSize+1)
+	for i := range itemPaths {
+		itemPaths[i] = "repo/dir/a.zip"
+	}
+	checksums, err = SearchChecksums(servicesManager, itemPaths)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"repo/dir/a.zip": "a-sha256"}, checksums)
+	assert.Equal(t, 2, queries)
+}
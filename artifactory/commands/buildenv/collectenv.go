@@ -0,0 +1,67 @@
+// Package buildenv implements the build-collect-env command, which collects the environment variables into the build-info
+// like the command of jfrog-cli-core does, while masking the values of the variables which hold secrets.
+package buildenv
+
+import (
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/redact"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type BuildCollectEnvCommand struct {
+	buildConfiguration *utils.BuildConfiguration
+}
+
+func NewBuildCollectEnvCommand() *BuildCollectEnvCommand {
+	return &BuildCollectEnvCommand{}
+}
+
+func (bcec *BuildCollectEnvCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildCollectEnvCommand {
+	bcec.buildConfiguration = buildConfiguration
+	return bcec
+}
+
+func (bcec *BuildCollectEnvCommand) Run() error {
+	log.Info("Collecting environment variables...")
+	err := utils.SaveBuildGeneralDetails(bcec.buildConfiguration.BuildName, bcec.buildConfiguration.BuildNumber, bcec.buildConfiguration.Project)
+	if err != nil {
+		return err
+	}
+	populateFunc := func(partial *buildinfo.Partial) {
+		partial.Env = getEnvVariables(os.Environ(), redact.GetPatterns())
+	}
+	err = utils.SavePartialBuildInfo(bcec.buildConfiguration.BuildName, bcec.buildConfiguration.BuildNumber, bcec.buildConfiguration.Project, populateFunc)
+	if err != nil {
+		return err
+	}
+	log.Info("Collected environment variables for", bcec.buildConfiguration.BuildName+"/"+bcec.buildConfiguration.BuildNumber+".")
+	return nil
+}
+
+// Returns the default configured Artifactory server
+func (bcec *BuildCollectEnvCommand) ServerDetails() (*config.ServerDetails, error) {
+	return config.GetDefaultServerConf()
+}
+
+func (bcec *BuildCollectEnvCommand) CommandName() string {
+	return "rt_build_collect_env"
+}
+
+// Returns the environment variables in the build-info format.
+// The values of the variables whose names match the redaction patterns are masked, and so are the secrets in the other values.
+// Variables excluded using the --env-exclude option are still removed when the build-info is published.
+func getEnvVariables(environ []string, redactPatterns []string) buildinfo.Env {
+	env := make(buildinfo.Env)
+	for _, e := range environ {
+		pair := strings.SplitN(e, "=", 2)
+		if len(pair) == 2 && len(pair[0]) != 0 {
+			env[buildinfo.BuildInfoEnvPrefix+pair[0]] = redact.EnvValue(pair[0], pair[1], redactPatterns)
+		}
+	}
+	return env
+}
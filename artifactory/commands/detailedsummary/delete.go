@@ -0,0 +1,94 @@
+package detailedsummary
+
+import (
+	"net/http"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/progressbar"
+	"github.com/jfrog/jfrog-cli/utils/tracing"
+	rtutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Deletes the artifacts matching the spec, recording the path of each deleted artifact.
+// The paths to delete are collected the same way as the delete command of jfrog-cli-core does.
+type DeleteCommand struct {
+	generic.DeleteCommand
+	progress ioUtils.ProgressMgr
+}
+
+func NewDeleteCommand() *DeleteCommand {
+	return &DeleteCommand{DeleteCommand: *generic.NewDeleteCommand()}
+}
+
+// Sets the progress manager. Since the deletion is confirmed interactively unless quiet,
+// the progress should be set only if quiet.
+func (dc *DeleteCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	dc.progress = progress
+}
+
+func (dc *DeleteCommand) Run() error {
+	searchDone := startSpecSearchProgress(dc.progress, dc.Spec().Files...)
+	reader, err := dc.GetPathsToDelete()
+	searchDone()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if !dc.Quiet() {
+		allowDelete, err := utils.ConfirmDelete(reader)
+		if err != nil || !allowDelete {
+			return err
+		}
+	}
+	if err = incProgressTotal(dc.progress, reader); err != nil {
+		return err
+	}
+	serverDetails, err := dc.ServerDetails()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateDeleteServiceManager(serverDetails, dc.Threads(), dc.Retries(), dc.DryRun())
+	if err != nil {
+		return err
+	}
+	artDetails := servicesManager.GetConfig().GetServiceDetails()
+	success, failed, records, err := performActions(servicesManager.GetConfig().GetThreads(), dc.DryRun(), func(addAction addActionFunc) error {
+		for resultItem := new(rtutils.ResultItem); reader.NextRecord(resultItem) == nil; resultItem = new(rtutils.ResultItem) {
+			itemPath := resultItem.GetItemRelativePath()
+			addAction("", func(logMsgPrefix string) (string, error) {
+				defer progressbar.StartItemProgress(dc.progress, "Deleting", itemPath)()
+				log.Info(logMsgPrefix+"Deleting", itemPath)
+				if dc.DryRun() {
+					return itemPath, nil
+				}
+				deleteUrl, err := rtutils.BuildArtifactoryUrl(artDetails.GetUrl(), itemPath, make(map[string]string))
+				if err != nil {
+					return itemPath, err
+				}
+				httpClientsDetails := artDetails.CreateHttpClientDetails()
+				resp, body, err := tracing.TraceHttpRequest(http.MethodDelete, deleteUrl, func() (*http.Response, []byte, error) {
+					return servicesManager.Client().SendDelete(deleteUrl, nil, &httpClientsDetails)
+				})
+				if err != nil {
+					return itemPath, err
+				}
+				if resp.StatusCode != http.StatusNoContent {
+					return itemPath, newResponseError(resp.Status, body)
+				}
+				return itemPath, nil
+			})
+		}
+		return reader.GetError()
+	})
+	result := dc.Result()
+	result.SetSuccessCount(success)
+	result.SetFailCount(failed)
+	result.SetReader(records)
+	if err != nil {
+		return err
+	}
+	return getFailuresError(failed, "deleting")
+}
@@ -0,0 +1,132 @@
+// Package detailedsummary implements the commands which act on existing artifacts, such as move, copy, delete and set-props,
+// while recording the outcome of each item and reporting the progress. The corresponding commands of jfrog-cli-core
+// report only the total counts, so these commands are used instead of them only when the items are requested,
+// by the --detailed-summary or the --summary-file options.
+package detailedsummary
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/progressbar"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-cli/utils/tracing"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The array key of the records in the result reader.
+const recordsKey = "files"
+
+// Performs an action on a single item. Returns the target path of the item.
+type itemAction func(logMsgPrefix string) (target string, err error)
+
+// Adds an action on the item in the source path, to be performed by performActions.
+type addActionFunc func(source string, action itemAction)
+
+// Performs the actions added by addActions, using the provided number of threads.
+// The outcome of each action is recorded as a cliutils.DetailedSummaryRecord, in the returned reader.
+// A failed action doesn't stop the other actions. Its error is recorded as the failure reason of the item.
+func performActions(threads int, dryRun bool, addActions func(addAction addActionFunc) error) (success, failed int, records *content.ContentReader, err error) {
+	writer, err := content.NewContentWriter(recordsKey, true, false)
+	if err != nil {
+		return
+	}
+	successCounters := make([]int, threads)
+	failedCounters := make([]int, threads)
+	producerConsumer := parallel.NewBounedRunner(threads, false)
+	errorsQueue := clientutils.NewErrorsQueue(1)
+	go func() {
+		defer producerConsumer.Done()
+		addAction := func(source string, action itemAction) {
+			producerConsumer.AddTaskWithError(func(threadId int) error {
+				logMsgPrefix := clientutils.GetLogMsgPrefix(threadId, dryRun)
+				target, actionErr := action(logMsgPrefix)
+				record := cliutils.DetailedSummaryRecord{Source: source, Target: target, Status: summary.StatusTypes[summary.Success]}
+				if actionErr != nil {
+					log.Error(logMsgPrefix + actionErr.Error())
+					record.Status = summary.StatusTypes[summary.Failure]
+					record.Reason = actionErr.Error()
+					failedCounters[threadId]++
+				} else {
+					successCounters[threadId]++
+				}
+				writer.Write(record)
+				return nil
+			}, errorsQueue.AddError)
+		}
+		if err := addActions(addAction); err != nil {
+			errorsQueue.AddError(err)
+		}
+	}()
+	producerConsumer.Run()
+	for i := range successCounters {
+		success += successCounters[i]
+		failed += failedCounters[i]
+	}
+	if err = writer.Close(); err != nil {
+		return
+	}
+	records = content.NewContentReader(writer.GetFilePath(), recordsKey)
+	err = errorsQueue.GetError()
+	return
+}
+
+// Returns an error if any of the items failed, like the commands of jfrog-cli-core do.
+func getFailuresError(failed int, action string) error {
+	if failed == 0 {
+		return nil
+	}
+	return errorutils.CheckError(errors.New("Failed " + action + " " + strconv.Itoa(failed) + " artifacts."))
+}
+
+// Creates the failure reason of an unexpected Artifactory response.
+func newResponseError(status string, body []byte) error {
+	if len(body) == 0 {
+		return errors.New("Artifactory response: " + status)
+	}
+	return errors.New("Artifactory response: " + status + "\n" + clientutils.IndentJson(body))
+}
+
+// Adds the items found by the search to the total of the progress, so that the progress of each item is reported against it.
+func incProgressTotal(progressMgr ioUtils.ProgressMgr, reader *content.ContentReader) error {
+	if progressMgr == nil {
+		return nil
+	}
+	length, err := reader.Length()
+	if err != nil {
+		return err
+	}
+	progressMgr.IncGeneralProgressTotalBy(int64(length))
+	return nil
+}
+
+// Displays the search for the items of the file specs, if the progress is reported,
+// and records the search as a tracing span, if tracing is enabled.
+func startSpecSearchProgress(progressMgr ioUtils.ProgressMgr, files ...spec.File) (done func()) {
+	var patterns []string
+	for _, file := range files {
+		switch {
+		case file.Pattern != "":
+			patterns = append(patterns, file.Pattern)
+		case file.Build != "":
+			patterns = append(patterns, "build "+file.Build)
+		default:
+			patterns = append(patterns, "AQL")
+		}
+	}
+	span := tracing.StartSpan("AQL search")
+	span.SetAttribute("jfrog.pattern", strings.Join(patterns, ", "))
+	progressDone := progressbar.StartSearchProgress(progressMgr, strings.Join(patterns, ", "))
+	return func() {
+		progressDone()
+		span.End()
+	}
+}
@@ -0,0 +1,55 @@
+package detailedsummary
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerformActions(t *testing.T) {
+	success, failed, records, err := performActions(2, false, func(addAction addActionFunc) error {
+		addAction("repo/a.zip", func(string) (string, error) {
+			return "other-repo/a.zip", nil
+		})
+		addAction("repo/b.zip", func(string) (string, error) {
+			return "other-repo/b.zip", errors.New("409 Conflict")
+		})
+		return nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer records.Close()
+	assert.Equal(t, 1, success)
+	assert.Equal(t, 1, failed)
+
+	recordsBySource := map[string]cliutils.DetailedSummaryRecord{}
+	for record := new(cliutils.DetailedSummaryRecord); records.NextRecord(record) == nil; record = new(cliutils.DetailedSummaryRecord) {
+		recordsBySource[record.Source] = *record
+	}
+	assert.NoError(t, records.GetError())
+	assert.Equal(t, cliutils.DetailedSummaryRecord{Source: "repo/a.zip", Target: "other-repo/a.zip", Status: "success"}, recordsBySource["repo/a.zip"])
+	assert.Equal(t, cliutils.DetailedSummaryRecord{Source: "repo/b.zip", Target: "other-repo/b.zip", Status: "failure", Reason: "409 Conflict"}, recordsBySource["repo/b.zip"])
+}
+
+func TestGetDestinationPath(t *testing.T) {
+	tests := []struct {
+		target       string
+		pattern      string
+		itemPath     string
+		relativePath string
+		flat         bool
+		expected     string
+	}{
+		{"other-repo/", "repo/*", "dir", "repo/dir/a.zip", false, "other-repo/dir/"},
+		{"other-repo/", "repo/*", "dir", "repo/dir/a.zip", true, "other-repo/"},
+		{"other-repo/{1}", "repo/(*).zip", ".", "repo/a.zip", true, "other-repo/a"},
+	}
+	for _, test := range tests {
+		destPath, err := getDestinationPath(test.target, test.pattern, test.itemPath, test.relativePath, test.flat)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, destPath, test)
+	}
+}
@@ -0,0 +1,250 @@
+package detailedsummary
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/progressbar"
+	"github.com/jfrog/jfrog-cli/utils/tracing"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	rtutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+var moveCopyMessages = map[services.MoveType]struct{ moving, failed string }{
+	services.MOVE: {moving: "Moving", failed: "moving"},
+	services.COPY: {moving: "Copying", failed: "copying"},
+}
+
+// Moves or copies the artifacts using the specified move pattern, recording the source and target path of each artifact.
+type MoveCopyCommand struct {
+	generic.GenericCommand
+	moveType services.MoveType
+	threads  int
+	progress ioUtils.ProgressMgr
+}
+
+func NewMoveCopyCommand(moveType services.MoveType) *MoveCopyCommand {
+	return &MoveCopyCommand{GenericCommand: *generic.NewGenericCommand(), moveType: moveType}
+}
+
+func (mc *MoveCopyCommand) SetThreads(threads int) *MoveCopyCommand {
+	mc.threads = threads
+	return mc
+}
+
+func (mc *MoveCopyCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	mc.progress = progress
+}
+
+func (mc *MoveCopyCommand) CommandName() string {
+	return "rt_" + string(mc.moveType)
+}
+
+func (mc *MoveCopyCommand) Run() error {
+	serverDetails, err := mc.ServerDetails()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManagerWithThreads(serverDetails, mc.DryRun(), mc.threads, mc.Retries())
+	if err != nil {
+		return err
+	}
+	moveCopyService := services.NewMoveCopyService(servicesManager.GetConfig().GetServiceDetails(), servicesManager.Client(), mc.moveType)
+	moveCopyService.DryRun = mc.DryRun()
+
+	var moveCopyParams []services.MoveCopyParams
+	for i := 0; i < len(mc.Spec().Files); i++ {
+		params, err := getMoveCopyParams(mc.Spec().Get(i))
+		if err != nil {
+			return err
+		}
+		moveCopyParams = append(moveCopyParams, params)
+	}
+	reader, err := mc.getPathsToMove(moveCopyService, moveCopyParams)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if err = incProgressTotal(mc.progress, reader); err != nil {
+		return err
+	}
+
+	success, failed, records, err := performActions(servicesManager.GetConfig().GetThreads(), mc.DryRun(), func(addAction addActionFunc) error {
+		for resultItem := new(services.MoveResultItem); reader.NextRecord(resultItem) == nil; resultItem = new(services.MoveResultItem) {
+			item, params := resultItem.ResultItem, moveCopyParams[resultItem.FileSpecId]
+			addAction(item.GetItemRelativePath(), func(logMsgPrefix string) (string, error) {
+				return mc.moveOrCopy(moveCopyService, item, params, logMsgPrefix)
+			})
+		}
+		return reader.GetError()
+	})
+	result := mc.Result()
+	result.SetSuccessCount(success)
+	result.SetFailCount(failed)
+	result.SetReader(records)
+	if err != nil {
+		return err
+	}
+	return getFailuresError(failed, moveCopyMessages[mc.moveType].failed)
+}
+
+// Searches the artifacts of all the file specs, and merges them into a single reader of services.MoveResultItem.
+// Each item keeps the index of its file spec, which is required for calculating its destination.
+func (mc *MoveCopyCommand) getPathsToMove(moveCopyService *services.MoveCopyService, moveCopyParams []services.MoveCopyParams) (*content.ContentReader, error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	for i, params := range moveCopyParams {
+		searchDone := startSpecSearchProgress(mc.progress, mc.Spec().Files[i])
+		reader, err := searchPathsToMove(moveCopyService, params)
+		searchDone()
+		if err != nil {
+			writer.Close()
+			return nil, err
+		}
+		for item := new(rtutils.ResultItem); reader.NextRecord(item) == nil; item = new(rtutils.ResultItem) {
+			writer.Write(services.MoveResultItem{ResultItem: *item, FileSpecId: i})
+		}
+		err = reader.GetError()
+		reader.Close()
+		if err != nil {
+			writer.Close()
+			return nil, err
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	mergedReader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	if mc.moveType != services.MOVE {
+		return mergedReader, nil
+	}
+	// Remove the top chain dirs, since moving a dir moves its content as well.
+	defer mergedReader.Close()
+	return rtutils.ReduceTopChainDirResult(services.MoveResultItem{}, mergedReader)
+}
+
+func searchPathsToMove(moveCopyService *services.MoveCopyService, params services.MoveCopyParams) (*content.ContentReader, error) {
+	log.Info("Searching artifacts...")
+	var reader *content.ContentReader
+	var err error
+	switch params.GetSpecType() {
+	case rtutils.BUILD:
+		reader, err = rtutils.SearchBySpecWithBuild(params.GetFile(), moveCopyService)
+	case rtutils.AQL:
+		reader, err = rtutils.SearchBySpecWithAql(params.GetFile(), moveCopyService, rtutils.NONE)
+	default:
+		params.SetIncludeDir(true)
+		var tempReader *content.ContentReader
+		tempReader, err = rtutils.SearchBySpecWithPattern(params.GetFile(), moveCopyService, rtutils.NONE)
+		if err != nil {
+			return nil, err
+		}
+		defer tempReader.Close()
+		if params.IsFlat() {
+			reader, err = rtutils.ReduceBottomChainDirResult(rtutils.ResultItem{}, tempReader)
+		} else {
+			reader, err = rtutils.ReduceTopChainDirResult(rtutils.ResultItem{}, tempReader)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	length, err := reader.Length()
+	rtutils.LogSearchResults(length)
+	return reader, err
+}
+
+// Moves or copies a single item, and returns its destination path.
+func (mc *MoveCopyCommand) moveOrCopy(moveCopyService *services.MoveCopyService, item rtutils.ResultItem, params services.MoveCopyParams, logMsgPrefix string) (string, error) {
+	destPath, err := getDestinationPath(params.GetFile().Target, params.GetFile().Pattern, item.Path, item.GetItemRelativePath(), params.IsFlat())
+	if err != nil {
+		return "", err
+	}
+	artDetails := moveCopyService.GetArtifactoryDetails()
+	httpClientsDetails := artDetails.CreateHttpClientDetails()
+	if strings.HasSuffix(destPath, "/") {
+		if item.Type != "folder" {
+			destPath += item.Name
+		} else if !mc.DryRun() {
+			// Create the destination dir, like jfrog-client-go does. A failure is reported by the move/copy request which follows.
+			if createDirUrl, err := rtutils.BuildArtifactoryUrl(artDetails.GetUrl(), destPath, map[string]string{}); err == nil {
+				tracing.TraceHttpRequest(http.MethodPut, createDirUrl, func() (*http.Response, []byte, error) {
+					return moveCopyService.GetJfrogHttpClient().SendPut(createDirUrl, nil, &httpClientsDetails)
+				})
+			}
+		}
+	}
+	sourcePath := item.GetItemRelativePath()
+	defer progressbar.StartItemProgress(mc.progress, moveCopyMessages[mc.moveType].moving, sourcePath)()
+	message := moveCopyMessages[mc.moveType].moving + " artifact: " + sourcePath + " to: " + destPath
+	queryParams := map[string]string{"to": destPath}
+	if mc.DryRun() {
+		log.Info(logMsgPrefix+"[Dry run]", message)
+		queryParams["dry"] = "1"
+	} else {
+		log.Info(logMsgPrefix + message)
+	}
+	requestUrl, err := rtutils.BuildArtifactoryUrl(artDetails.GetUrl(), path.Join("api", string(mc.moveType), sourcePath), queryParams)
+	if err != nil {
+		return destPath, err
+	}
+	resp, body, err := tracing.TraceHttpRequest(http.MethodPost, requestUrl, func() (*http.Response, []byte, error) {
+		return moveCopyService.GetJfrogHttpClient().SendPost(requestUrl, nil, &httpClientsDetails)
+	})
+	if err != nil {
+		return destPath, err
+	}
+	log.Debug(logMsgPrefix+"Artifactory response:", resp.Status)
+	if resp.StatusCode != http.StatusOK {
+		return destPath, newResponseError(resp.Status, body)
+	}
+	return destPath, nil
+}
+
+// Creates the destination path of the move/copy, the same way jfrog-client-go does.
+func getDestinationPath(specTarget, specPattern, sourceItemPath, sourceItemRelativePath string, isFlat bool) (string, error) {
+	destPathLocal := specTarget
+	if !isFlat {
+		if strings.Contains(destPathLocal, "/") {
+			file, dir := fileutils.GetFileAndDirFromPath(destPathLocal)
+			destPathLocal = clientutils.TrimPath(dir + "/" + sourceItemPath + "/" + file)
+		} else {
+			destPathLocal = clientutils.TrimPath(destPathLocal + "/" + sourceItemPath + "/")
+		}
+	}
+	// Apply placeholders.
+	return clientutils.BuildTargetPath(specPattern, sourceItemRelativePath, destPathLocal, true)
+}
+
+func getMoveCopyParams(f *spec.File) (moveCopyParams services.MoveCopyParams, err error) {
+	moveCopyParams = services.NewMoveCopyParams()
+	moveCopyParams.ArtifactoryCommonParams, err = f.ToArtifactoryCommonParams()
+	if err != nil {
+		return
+	}
+	moveCopyParams.Recursive, err = f.IsRecursive(true)
+	if err != nil {
+		return
+	}
+	moveCopyParams.ExcludeArtifacts, err = f.IsExcludeArtifacts(false)
+	if err != nil {
+		return
+	}
+	moveCopyParams.IncludeDeps, err = f.IsIncludeDeps(false)
+	if err != nil {
+		return
+	}
+	moveCopyParams.Flat, err = f.IsFlat(false)
+	return
+}
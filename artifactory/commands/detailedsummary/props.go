@@ -0,0 +1,150 @@
+package detailedsummary
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/tracing"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	rtutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Sets or deletes the properties of the artifacts matching the spec, recording the path of each artifact.
+type PropsCommand struct {
+	generic.PropsCommand
+	deleteProps bool
+}
+
+func NewSetPropsCommand(command generic.PropsCommand) *PropsCommand {
+	return &PropsCommand{PropsCommand: command}
+}
+
+func NewDeletePropsCommand(command generic.PropsCommand) *PropsCommand {
+	return &PropsCommand{PropsCommand: command, deleteProps: true}
+}
+
+func (pc *PropsCommand) CommandName() string {
+	if pc.deleteProps {
+		return "rt_delete_properties"
+	}
+	return "rt_set_properties"
+}
+
+func (pc *PropsCommand) Run() error {
+	encodedProps, err := pc.getEncodedProps()
+	if err != nil {
+		return err
+	}
+	serverDetails, err := pc.ServerDetails()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManagerWithThreads(serverDetails, false, pc.Threads(), pc.Retries())
+	if err != nil {
+		return err
+	}
+	searchDone := startSpecSearchProgress(nil, pc.Spec().Files...)
+	reader, err := searchItems(pc.Spec(), servicesManager)
+	searchDone()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	artDetails := servicesManager.GetConfig().GetServiceDetails()
+	action, method, sendRequest := "Setting properties on:", http.MethodPut, servicesManager.Client().SendPut
+	if pc.deleteProps {
+		action, method, sendRequest = "Deleting properties on:", http.MethodDelete, servicesManager.Client().SendDelete
+	}
+	success, failed, records, err := performActions(servicesManager.GetConfig().GetThreads(), false, func(addAction addActionFunc) error {
+		for resultItem := new(rtutils.ResultItem); reader.NextRecord(resultItem) == nil; resultItem = new(rtutils.ResultItem) {
+			itemPath := resultItem.GetItemRelativePath()
+			addAction("", func(logMsgPrefix string) (string, error) {
+				log.Info(logMsgPrefix+action, itemPath)
+				propsUrl, err := rtutils.BuildArtifactoryUrl(artDetails.GetUrl(), path.Join("api", "storage", itemPath), make(map[string]string))
+				if err != nil {
+					return itemPath, err
+				}
+				// The search already took into account the recursive option, so the action itself shouldn't be recursive.
+				propsUrl += "?properties=" + encodedProps + "&recursive=0"
+				httpClientsDetails := artDetails.CreateHttpClientDetails()
+				resp, body, err := tracing.TraceHttpRequest(method, propsUrl, func() (*http.Response, []byte, error) {
+					return sendRequest(propsUrl, nil, &httpClientsDetails)
+				})
+				if err != nil {
+					return itemPath, err
+				}
+				if resp.StatusCode != http.StatusNoContent {
+					return itemPath, newResponseError(resp.Status, body)
+				}
+				return itemPath, nil
+			})
+		}
+		return reader.GetError()
+	})
+	result := pc.Result()
+	result.SetSuccessCount(success)
+	result.SetFailCount(failed)
+	result.SetReader(records)
+	if err != nil {
+		return err
+	}
+	if pc.deleteProps {
+		return getFailuresError(failed, "deleting properties of")
+	}
+	return getFailuresError(failed, "setting properties of")
+}
+
+// Encodes the properties the same way jfrog-client-go does.
+func (pc *PropsCommand) getEncodedProps() (string, error) {
+	if !pc.deleteProps {
+		props, err := rtutils.ParseProperties(pc.Props())
+		if err != nil {
+			return "", err
+		}
+		return props.ToEncodedString(true), nil
+	}
+	var encodedProps []string
+	for _, prop := range strings.Split(pc.Props(), ",") {
+		encodedProps = append(encodedProps, url.QueryEscape(prop))
+	}
+	return strings.Join(encodedProps, ","), nil
+}
+
+// Searches the artifacts of all the file specs, the same way the props commands of jfrog-cli-core do.
+func searchItems(spec *spec.SpecFiles, servicesManager artifactory.ArtifactoryServicesManager) (*content.ContentReader, error) {
+	errorOccurred := false
+	var readers []*content.ContentReader
+	defer func() {
+		for _, reader := range readers {
+			reader.Close()
+		}
+	}()
+	for i := 0; i < len(spec.Files); i++ {
+		searchParams, err := utils.GetSearchParams(spec.Get(i))
+		if err != nil {
+			errorOccurred = true
+			log.Error(err)
+			continue
+		}
+		reader, err := servicesManager.SearchFiles(searchParams)
+		if err != nil {
+			errorOccurred = true
+			log.Error(err)
+			continue
+		}
+		readers = append(readers, reader)
+	}
+	if errorOccurred {
+		return nil, errorutils.CheckError(errors.New("Operation finished with errors, please review the logs."))
+	}
+	return content.MergeReaders(readers, content.DefaultKey)
+}
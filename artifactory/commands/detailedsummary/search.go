@@ -0,0 +1,25 @@
+package detailedsummary
+
+import (
+	"github.com/jfrog/jfrog-cli-core/artifactory/commands/generic"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+)
+
+// Searches the artifacts the same way as the search command of jfrog-cli-core does, while displaying the search progress.
+type SearchCommand struct {
+	generic.SearchCommand
+	progress ioUtils.ProgressMgr
+}
+
+func NewSearchCommand() *SearchCommand {
+	return &SearchCommand{SearchCommand: *generic.NewSearchCommand()}
+}
+
+func (sc *SearchCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	sc.progress = progress
+}
+
+func (sc *SearchCommand) Run() error {
+	defer startSpecSearchProgress(sc.progress, sc.Spec().Files...)()
+	return sc.SearchCommand.Run()
+}
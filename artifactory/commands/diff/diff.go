@@ -0,0 +1,239 @@
+// Package diff implements the diff command, which compares a local directory with a repository path, or two repository paths,
+// possibly on two different servers. The local files are resolved the same way the upload command resolves them,
+// and the repository paths are searched the same way the search command searches them.
+package diff
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/aqlitems"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/localfiles"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The statuses of the differing files.
+const (
+	// The file exists only in the source, which is the local directory or the first repository path.
+	SourceOnly = "source-only"
+	// The file exists only in the target repository path.
+	TargetOnly = "target-only"
+	// The file exists in both, with a different checksum.
+	Modified = "modified"
+)
+
+// A file which differs between the source and the target.
+type Record struct {
+	// The path of the file, relative to the compared directories.
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	// The local path or the repository path of the file in the source. Empty if the file exists only in the target.
+	Source       string `json:"source,omitempty"`
+	SourceSha256 string `json:"sourceSha256,omitempty"`
+	// The repository path of the file in the target. Empty if the file exists only in the source.
+	Target       string `json:"target,omitempty"`
+	TargetSha256 string `json:"targetSha256,omitempty"`
+}
+
+// A file in the source or the target.
+type fileEntry struct {
+	path   string
+	sha1   string
+	sha256 string
+}
+
+type DiffCommand struct {
+	serverDetails       *config.ServerDetails
+	sourceServerDetails *config.ServerDetails
+	spec                *spec.SpecFiles
+	remoteSource        bool
+	retries             int
+	result              []*Record
+}
+
+func NewDiffCommand() *DiffCommand {
+	return &DiffCommand{}
+}
+
+// Sets the server of the target repository path.
+func (dc *DiffCommand) SetServerDetails(serverDetails *config.ServerDetails) *DiffCommand {
+	dc.serverDetails = serverDetails
+	return dc
+}
+
+// Sets the server of the source repository path, if it differs from the server of the target.
+func (dc *DiffCommand) SetSourceServerDetails(serverDetails *config.ServerDetails) *DiffCommand {
+	dc.sourceServerDetails = serverDetails
+	return dc
+}
+
+// Sets the compared paths. The pattern of each file spec is the source, and its target is the target repository path.
+func (dc *DiffCommand) SetSpec(spec *spec.SpecFiles) *DiffCommand {
+	dc.spec = spec
+	return dc
+}
+
+// Sets whether the source is a repository path, rather than a local directory.
+func (dc *DiffCommand) SetRemoteSource(remoteSource bool) *DiffCommand {
+	dc.remoteSource = remoteSource
+	return dc
+}
+
+func (dc *DiffCommand) SetRetries(retries int) *DiffCommand {
+	dc.retries = retries
+	return dc
+}
+
+func (dc *DiffCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dc.serverDetails, nil
+}
+
+func (dc *DiffCommand) CommandName() string {
+	return "rt_diff"
+}
+
+// Returns the differing files, sorted by their paths.
+func (dc *DiffCommand) Result() []*Record {
+	return dc.result
+}
+
+func (dc *DiffCommand) Run() error {
+	targetManager, err := utils.CreateServiceManager(dc.serverDetails, dc.retries, false)
+	if err != nil {
+		return err
+	}
+	sourceManager := targetManager
+	if dc.remoteSource && dc.sourceServerDetails != nil {
+		if sourceManager, err = utils.CreateServiceManager(dc.sourceServerDetails, dc.retries, false); err != nil {
+			return err
+		}
+	}
+	dc.result = nil
+	for i := 0; i < len(dc.spec.Files); i++ {
+		file := dc.spec.Get(i)
+		targetPattern, targetRoot := getTargetPattern(strings.TrimPrefix(file.Target, "/"))
+		var sourceFiles map[string]*fileEntry
+		if dc.remoteSource {
+			sourceFiles, err = searchRemoteFiles(sourceManager, file, getRemoteRoot(file.Pattern))
+		} else {
+			sourceFiles, err = getLocalFiles(file, targetRoot)
+		}
+		if err != nil {
+			return err
+		}
+		targetSpec := spec.NewBuilder().Pattern(targetPattern).Recursive(true).BuildSpec()
+		targetFiles, err := searchRemoteFiles(targetManager, targetSpec.Get(0), targetRoot)
+		if err != nil {
+			return err
+		}
+		dc.result = append(dc.result, compare(sourceFiles, targetFiles)...)
+	}
+	log.Info("Found", len(dc.result), "differing files.")
+	return nil
+}
+
+// Returns the records of the files which exist only in the source or only in the target, or differ in their checksums.
+func compare(sourceFiles, targetFiles map[string]*fileEntry) []*Record {
+	var records []*Record
+	for path, source := range sourceFiles {
+		target, exists := targetFiles[path]
+		switch {
+		case !exists:
+			records = append(records, &Record{Path: path, Status: SourceOnly, Source: source.path, SourceSha256: source.sha256})
+		case !isSameContent(source, target):
+			records = append(records, &Record{Path: path, Status: Modified, Source: source.path, SourceSha256: source.sha256, Target: target.path, TargetSha256: target.sha256})
+		}
+	}
+	for path, target := range targetFiles {
+		if _, exists := sourceFiles[path]; !exists {
+			records = append(records, &Record{Path: path, Status: TargetOnly, Target: target.path, TargetSha256: target.sha256})
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Path < records[j].Path
+	})
+	return records
+}
+
+// Compares the sha256 checksums of the files. Artifactory may not have calculated the sha256 of old artifacts,
+// in which case the sha1 checksums are compared instead.
+func isSameContent(source, target *fileEntry) bool {
+	if source.sha256 != "" && target.sha256 != "" {
+		return source.sha256 == target.sha256
+	}
+	return source.sha1 == target.sha1
+}
+
+// Returns the pattern to search the target files with, and the root the paths of the files are relative to.
+// If the target is a directory, or includes placeholders, all the files under it are the target files.
+// Otherwise, the target is a single file.
+func getTargetPattern(target string) (pattern, root string) {
+	if !strings.Contains(target, "/") {
+		target += "/"
+	}
+	root = getRemoteRoot(target)
+	if strings.HasSuffix(target, "/") || strings.ContainsAny(target, "*?{") {
+		return root, root
+	}
+	return target, root
+}
+
+// Returns the directory of the repository path, up to its first wildcard or placeholder, such as 'repo/dir/' for 'repo/dir/*.zip'.
+func getRemoteRoot(pattern string) string {
+	pattern = strings.TrimPrefix(pattern, "/")
+	if i := strings.IndexAny(pattern, "*?{("); i >= 0 {
+		pattern = pattern[:i]
+	}
+	if !strings.Contains(pattern, "/") {
+		return pattern + "/"
+	}
+	return pattern[:strings.LastIndex(pattern, "/")+1]
+}
+
+// Collects the local files matching the file spec, the same way the upload command does.
+// Returns the files by their upload target paths, relative to the target root.
+func getLocalFiles(file *spec.File, targetRoot string) (map[string]*fileEntry, error) {
+	localFiles, err := localfiles.Collect(file)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*fileEntry)
+	for _, localFile := range localFiles {
+		sha1, sha256, err := localfiles.CalcChecksums(localFile.LocalPath)
+		if err != nil {
+			return nil, err
+		}
+		files[strings.TrimPrefix(localFile.TargetPath, targetRoot)] = &fileEntry{path: localFile.LocalPath, sha1: sha1, sha256: sha256}
+	}
+	return files, nil
+}
+
+// Searches the files matching the file spec, the same way the search command does, including their sha256 checksums.
+// Returns the files by their paths relative to the root.
+func searchRemoteFiles(servicesManager artifactory.ArtifactoryServicesManager, file *spec.File, root string) (map[string]*fileEntry, error) {
+	searchParams, err := utils.GetSearchParams(file)
+	if err != nil {
+		return nil, err
+	}
+	if searchParams.Build != "" || searchParams.Bundle != "" {
+		return nil, errorutils.CheckError(errors.New("the diff command doesn't support the build and bundle options"))
+	}
+	searchParams.IncludeDirs = false
+	reader, err := aqlitems.SearchSpec(servicesManager, searchParams.ArtifactoryCommonParams)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	files := make(map[string]*fileEntry)
+	for item := new(aqlitems.Item); reader.NextRecord(item) == nil; item = new(aqlitems.Item) {
+		itemPath := item.GetItemRelativePath()
+		files[strings.TrimPrefix(itemPath, root)] = &fileEntry{path: itemPath, sha1: item.Actual_Sha1, sha256: item.Sha256}
+	}
+	return files, reader.GetError()
+}
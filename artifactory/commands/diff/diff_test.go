@@ -0,0 +1,51 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	source := map[string]*fileEntry{
+		"a.zip":     {path: "build/a.zip", sha1: "1", sha256: "a"},
+		"b.zip":     {path: "build/b.zip", sha1: "2", sha256: "b"},
+		"dir/c.zip": {path: "build/dir/c.zip", sha1: "3", sha256: "c"},
+		"d.zip":     {path: "build/d.zip", sha1: "4", sha256: "d"},
+	}
+	target := map[string]*fileEntry{
+		"a.zip": {path: "repo/a.zip", sha1: "1", sha256: "a"},
+		"b.zip": {path: "repo/b.zip", sha1: "2", sha256: "modified"},
+		// Artifactory didn't calculate the sha256, so the sha1 is compared.
+		"d.zip":     {path: "repo/d.zip", sha1: "4"},
+		"dir/e.zip": {path: "repo/dir/e.zip", sha1: "5", sha256: "e"},
+	}
+	assert.Equal(t, []*Record{
+		{Path: "b.zip", Status: Modified, Source: "build/b.zip", SourceSha256: "b", Target: "repo/b.zip", TargetSha256: "modified"},
+		{Path: "dir/c.zip", Status: SourceOnly, Source: "build/dir/c.zip", SourceSha256: "c"},
+		{Path: "dir/e.zip", Status: TargetOnly, Target: "repo/dir/e.zip", TargetSha256: "e"},
+	}, compare(source, target))
+}
+
+func TestGetTargetPattern(t *testing.T) {
+	tests := []struct {
+		target          string
+		expectedPattern string
+		expectedRoot    string
+	}{
+		{"repo", "repo/", "repo/"},
+		{"repo/dir/", "repo/dir/", "repo/dir/"},
+		{"repo/dir/{1}/", "repo/dir/", "repo/dir/"},
+		{"repo/dir/{1}.zip", "repo/dir/", "repo/dir/"},
+		{"repo/dir/a.zip", "repo/dir/a.zip", "repo/dir/"},
+	}
+	for _, test := range tests {
+		t.Run(test.target, func(t *testing.T) {
+			pattern, root := getTargetPattern(test.target)
+			assert.Equal(t, test.expectedPattern, pattern)
+			assert.Equal(t, test.expectedRoot, root)
+		})
+	}
+	assert.Equal(t, "repo/dir/", getRemoteRoot("/repo/dir/*.zip"))
+	assert.Equal(t, "repo/", getRemoteRoot("repo/(*).zip"))
+}
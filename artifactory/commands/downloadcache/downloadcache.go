@@ -0,0 +1,81 @@
+// Package downloadcache connects the download command to the local download cache. Before the download, the cached artifacts
+// are copied to the paths they would be downloaded to, according to their sha256 checksums.
+// The download command then finds them locally with the expected checksum, and doesn't download them.
+// After the download, the downloaded artifacts are added to the cache.
+package downloadcache
+
+import (
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli/artifactory/commands/downloaditems"
+	cacheutils "github.com/jfrog/jfrog-cli/utils/cache"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type DownloadCache struct {
+	cache *cacheutils.Cache
+	// The sha256 checksums of the artifacts to download, by their absolute local paths.
+	checksums map[string]string
+	hits      int
+}
+
+func New(cache *cacheutils.Cache) *DownloadCache {
+	return &DownloadCache{cache: cache, checksums: make(map[string]string)}
+}
+
+// Returns the number of artifacts which were copied from the cache.
+func (dc *DownloadCache) Hits() int {
+	return dc.hits
+}
+
+// Copies the cached artifacts to their local paths. The artifacts are searched by the downloaditems package.
+func (dc *DownloadCache) Prepare(items map[string]*downloaditems.Item) error {
+	for localPath, item := range items {
+		dc.checksums[localPath] = item.Sha256
+		hit, err := dc.cache.Get(item.Sha256, item.Size, localPath)
+		if err != nil {
+			return err
+		}
+		if hit {
+			log.Debug("Copied", item.GetItemRelativePath(), "from the download cache.")
+			dc.hits++
+		}
+	}
+	if dc.hits > 0 {
+		log.Info("Found", dc.hits, "of", len(items), "artifacts in the download cache.")
+	}
+	return nil
+}
+
+// Adds the downloaded artifacts, as listed by the reader of the download command result, to the cache,
+// and evicts the least recently used files if the cache exceeds its maximal size. The reader is reset, so it can be read again.
+func (dc *DownloadCache) Update(reader *content.ContentReader) error {
+	if reader != nil {
+		defer reader.Reset()
+		for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+			localPath, err := filepath.Abs(transferDetails.TargetPath)
+			if errorutils.CheckError(err) != nil {
+				return err
+			}
+			sha256, exists := dc.checksums[localPath]
+			if !exists {
+				continue
+			}
+			// Extracted archives are removed after the download.
+			if !fileutils.IsPathExists(localPath, false) {
+				continue
+			}
+			if err = dc.cache.Add(sha256, localPath); err != nil {
+				return err
+			}
+		}
+		if err := reader.GetError(); err != nil {
+			return err
+		}
+	}
+	return dc.cache.Evict()
+}
@@ -0,0 +1,53 @@
+package downloadcache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cacheutils "github.com/jfrog/jfrog-cli/utils/cache"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+// The sha256 of 'a.zip'.
+const aSha256 = "d42cf35afe0bcfc26772d92fe13bf3cdaf9b8cf8f0df8eebf569e6a88536e72f"
+
+func TestUpdate(t *testing.T) {
+	log.SetLogger(log.NewLogger(log.INFO, nil))
+	tempDir, err := ioutil.TempDir("", "downloadcache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	cache, err := cacheutils.New(filepath.Join(tempDir, "cache"))
+	assert.NoError(t, err)
+	downloadCache := New(cache)
+	aPath, bPath := filepath.Join(tempDir, "a.zip"), filepath.Join(tempDir, "b.zip")
+	assert.NoError(t, ioutil.WriteFile(aPath, []byte("a.zip"), 0644))
+	assert.NoError(t, ioutil.WriteFile(bPath, []byte("b.zip"), 0644))
+	// The checksum of b.zip wasn't found in the search, so it isn't cached.
+	downloadCache.checksums[aPath] = aSha256
+
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	assert.NoError(t, err)
+	writer.Write(clientutils.FileTransferDetails{SourcePath: "https://acme.jfrog.io/artifactory/repo/a.zip", TargetPath: aPath})
+	writer.Write(clientutils.FileTransferDetails{SourcePath: "https://acme.jfrog.io/artifactory/repo/b.zip", TargetPath: bPath})
+	assert.NoError(t, writer.Close())
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer reader.Close()
+	assert.NoError(t, downloadCache.Update(reader))
+
+	stats, err := cache.Stats()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.Files)
+	// The reader is reset, so it can be read again.
+	length, err := reader.Length()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, length)
+
+	hit, err := cache.Get(aSha256, 5, filepath.Join(tempDir, "workspace", "a.zip"))
+	assert.NoError(t, err)
+	assert.True(t, hit)
+}
@@ -0,0 +1,161 @@
+// Package downloaditems searches the artifacts of a download spec using the search of the download service of jfrog-client-go,
+// and resolves the local paths the download service downloads them to, without downloading them.
+// Unlike the search results of the download service, the artifacts include their sha256 checksums.
+// The download service doesn't export its search or its resolution of the local paths, so searchLikeDownload and getLocalPath
+// repeat them, and the artifacts are searched again, before the download service searches them itself.
+// TestSearchLocalPathsMatchDownload compares the resolved local paths with the paths the download command downloads to.
+package downloaditems
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/aqlitems"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	rtutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils/version"
+)
+
+// An artifact to download.
+type Item struct {
+	rtutils.ResultItem
+	Sha256 string `json:"sha256"`
+	// The absolute local path the artifact is downloaded to.
+	LocalPath string `json:"-"`
+}
+
+// Searches the artifacts of the download spec. Returns the artifacts by their absolute local paths.
+// Build and bundle file specs aren't searched, since their artifacts are filtered by the build-info.
+func SearchSpec(downloadSpec *spec.SpecFiles, serverDetails *config.ServerDetails, retries int) (map[string]*Item, error) {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, retries, false)
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[string]*Item)
+	for i := 0; i < len(downloadSpec.Files); i++ {
+		file := downloadSpec.Get(i)
+		if file.Build != "" || file.Bundle != "" {
+			log.Debug("The artifacts of builds and bundles aren't searched in advance.")
+			continue
+		}
+		fileItems, err := Search(servicesManager, file)
+		if err != nil {
+			return nil, err
+		}
+		for localPath, item := range fileItems {
+			items[localPath] = item
+		}
+	}
+	return items, nil
+}
+
+// Searches the artifacts matching the file spec, using the search of the download service of jfrog-client-go.
+// Returns the artifacts by their absolute local paths, resolved the same way the download service resolves them.
+// Like the download service, only the first of the artifacts which would be downloaded to the same local path is returned.
+func Search(servicesManager artifactory.ArtifactoryServicesManager, file *spec.File) (map[string]*Item, error) {
+	searchParams, err := utils.GetSearchParams(file)
+	if err != nil {
+		return nil, err
+	}
+	searchParams.IncludeDirs = false
+	searchParams.Transitive = strings.ToLower(os.Getenv(coreutils.TransitiveDownload)) == "true"
+	flat, err := file.IsFlat(false)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := searchLikeDownload(servicesManager, searchParams.ArtifactoryCommonParams)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	getLocalPathFunc := func(result interface{}) (string, error) {
+		resultItem := new(rtutils.ResultItem)
+		if err := content.ConvertToStruct(result, &resultItem); err != nil {
+			return "", err
+		}
+		return getLocalPath(searchParams.Pattern, searchParams.Target, flat, resultItem)
+	}
+	sortedReader, err := content.SortContentReaderByCalculatedKey(reader, getLocalPathFunc, true)
+	if err != nil {
+		return nil, err
+	}
+	defer sortedReader.Close()
+	items := make(map[string]*Item)
+	var itemPaths []string
+	for resultItem := new(rtutils.ResultItem); sortedReader.NextRecord(resultItem) == nil; resultItem = new(rtutils.ResultItem) {
+		if resultItem.Type == "folder" {
+			continue
+		}
+		item := &Item{ResultItem: *resultItem}
+		if item.LocalPath, err = getLocalPath(searchParams.Pattern, searchParams.Target, flat, resultItem); err != nil {
+			return nil, err
+		}
+		items[item.LocalPath] = item
+		itemPaths = append(itemPaths, item.GetItemRelativePath())
+	}
+	if err = sortedReader.GetError(); err != nil {
+		return nil, err
+	}
+	// The search results of jfrog-client-go don't include the sha256 checksums, so they're searched by the artifacts paths.
+	checksums, err := aqlitems.SearchChecksums(servicesManager, itemPaths)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		item.Sha256 = checksums[item.GetItemRelativePath()]
+	}
+	return items, nil
+}
+
+// Searches the artifacts the same way the download service of jfrog-client-go searches them.
+// Repeats the search of the download service, and should be kept in line with the jfrog-client-go version in use.
+// Returns a reader of rtutils.ResultItem records, which should be closed.
+func searchLikeDownload(servicesManager artifactory.ArtifactoryServicesManager, params *rtutils.ArtifactoryCommonParams) (*content.ContentReader, error) {
+	artifactoryVersion, err := servicesManager.GetVersion()
+	if err != nil {
+		return nil, err
+	}
+	rtutils.DisableTransitiveSearchIfNotAllowed(params, version.NewVersion(artifactoryVersion))
+	flags := &searchFlags{servicesManager: servicesManager}
+	if params.GetSpecType() == rtutils.AQL {
+		return rtutils.SearchBySpecWithAql(params, flags, rtutils.SYMLINK)
+	}
+	return rtutils.SearchBySpecWithPattern(params, flags, rtutils.SYMLINK)
+}
+
+// Provides the Artifactory details and the HTTP client of the services manager to the search functions of jfrog-client-go.
+type searchFlags struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+}
+
+func (sf *searchFlags) GetArtifactoryDetails() auth.ServiceDetails {
+	return sf.servicesManager.GetConfig().GetServiceDetails()
+}
+
+func (sf *searchFlags) GetJfrogHttpClient() *jfroghttpclient.JfrogHttpClient {
+	return sf.servicesManager.Client()
+}
+
+// Returns the absolute local path the download service downloads the artifact to.
+// Repeats the resolution of the local path of the download service, and should be kept in line with the jfrog-client-go version in use.
+func getLocalPath(pattern, target string, flat bool, item *rtutils.ResultItem) (string, error) {
+	target, err := clientutils.BuildTargetPath(pattern, item.GetItemRelativePath(), target, true)
+	if err != nil {
+		return "", err
+	}
+	localPath, localFileName := fileutils.GetLocalPathAndFile(item.Name, item.Path, target, flat)
+	absPath, err := filepath.Abs(filepath.Join(localPath, localFileName))
+	return absPath, errorutils.CheckError(err)
+}
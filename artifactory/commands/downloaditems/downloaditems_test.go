@@ -0,0 +1,170 @@
+package downloaditems
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	rtutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLocalPath(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	item := &rtutils.ResultItem{Repo: "repo", Path: "dir/sub", Name: "a.zip"}
+	tests := []struct {
+		pattern  string
+		target   string
+		flat     bool
+		expected string
+	}{
+		{"repo/dir/*", "", false, filepath.Join(wd, "dir", "sub", "a.zip")},
+		{"repo/dir/*", "out/", true, filepath.Join(wd, "out", "a.zip")},
+		{"repo/dir/*", "out/", false, filepath.Join(wd, "out", "dir", "sub", "a.zip")},
+		{"repo/dir/(*)/a.zip", "out/{1}.zip", true, filepath.Join(wd, "out", "sub.zip")},
+	}
+	for _, test := range tests {
+		t.Run(test.pattern+"->"+test.target, func(t *testing.T) {
+			localPath, err := getLocalPath(test.pattern, test.target, test.flat, item)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, localPath)
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/system/version" {
+			_, _ = w.Write([]byte(`{"version":"7.20.0"}`))
+			return
+		}
+		query, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		if strings.Contains(string(query), `"sha256"`) {
+			// The checksums search.
+			_, _ = w.Write([]byte(`{"results":[{"repo":"repo","path":"dir1","name":"a.zip","type":"file","sha256":"a1-sha256"},` +
+				`{"repo":"repo","path":"dir2","name":"b.zip","type":"file","sha256":"b-sha256"}]}`))
+			return
+		}
+		// The search of the download service. Both a.zip artifacts are downloaded to the same flat path.
+		_, _ = w.Write([]byte(`{"results":[{"repo":"repo","path":"dir1","name":"a.zip","type":"file"},` +
+			`{"repo":"repo","path":"dir2","name":"a.zip","type":"file"},` +
+			`{"repo":"repo","path":"dir2","name":"b.zip","type":"file"},` +
+			`{"repo":"repo","path":"dir2","name":"sub","type":"folder"}]}`))
+	}))
+	defer server.Close()
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, false)
+	if !assert.NoError(t, err) {
+		return
+	}
+	file := spec.NewBuilder().Pattern("repo/*").Target("out/").Flat(true).BuildSpec().Get(0)
+	items, err := Search(servicesManager, file)
+	if !assert.NoError(t, err) {
+		return
+	}
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	if !assert.Len(t, items, 2) {
+		return
+	}
+	// Like the download service, only the first artifact downloaded to the same path is kept.
+	aItem := items[filepath.Join(wd, "out", "a.zip")]
+	if assert.NotNil(t, aItem) {
+		assert.Equal(t, "repo/dir1/a.zip", aItem.GetItemRelativePath())
+		assert.Equal(t, "a1-sha256", aItem.Sha256)
+	}
+	bItem := items[filepath.Join(wd, "out", "b.zip")]
+	if assert.NotNil(t, bItem) {
+		assert.Equal(t, "b-sha256", bItem.Sha256)
+	}
+}
+
+// Compares the local paths resolved by Search with the paths the download command downloads the artifacts to,
+// since the local paths are resolved by repeating the logic of the download service.
+func TestSearchLocalPathsMatchDownload(t *testing.T) {
+	items := []rtutils.ResultItem{
+		{Repo: "repo", Path: "dir", Name: "a.zip", Type: "file", Size: 5},
+		{Repo: "repo", Path: "dir/sub", Name: "a.zip", Type: "file", Size: 5},
+		{Repo: "repo", Path: "dir/sub/deep", Name: "b.zip", Type: "file", Size: 5},
+	}
+	tests := []struct {
+		name    string
+		spec    *spec.SpecFiles
+		results []rtutils.ResultItem
+	}{
+		{"flat", spec.NewBuilder().Pattern("repo/dir/sub/*").Target("out/").Flat(true).BuildSpec(), items[1:]},
+		{"recursive", spec.NewBuilder().Pattern("repo/dir/").Target("out/").Recursive(true).BuildSpec(), items},
+		{"placeholder", spec.NewBuilder().Pattern("repo/dir/(*)/a.zip").Target("out/{1}/renamed.zip").Flat(true).BuildSpec(), items[1:2]},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempDir, err := ioutil.TempDir("", "downloaditems")
+			assert.NoError(t, err)
+			defer os.RemoveAll(tempDir)
+			wd, err := os.Getwd()
+			assert.NoError(t, err)
+			assert.NoError(t, os.Chdir(tempDir))
+			defer os.Chdir(wd)
+			// The symlinks in the path of the temp dir are resolved, like the download command resolves them.
+			tempDir, err = os.Getwd()
+			assert.NoError(t, err)
+
+			server := createArtifactoryServer(t, test.results)
+			defer server.Close()
+			serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/"}
+			servicesManager, err := utils.CreateServiceManager(serverDetails, 0, false)
+			if !assert.NoError(t, err) {
+				return
+			}
+			searched, err := Search(servicesManager, test.spec.Get(0))
+			if !assert.NoError(t, err) {
+				return
+			}
+			var searchedPaths []string
+			for localPath := range searched {
+				searchedPaths = append(searchedPaths, localPath)
+			}
+
+			downloadCommand := generic.NewDownloadCommand()
+			downloadCommand.SetConfiguration(&utils.DownloadConfiguration{Threads: 1}).SetBuildConfiguration(&utils.BuildConfiguration{}).SetServerDetails(serverDetails).SetSpec(test.spec)
+			assert.NoError(t, downloadCommand.Run())
+			assert.Zero(t, downloadCommand.Result().FailCount())
+			var downloadedPaths []string
+			assert.NoError(t, filepath.Walk(tempDir, func(path string, info os.FileInfo, err error) error {
+				if err == nil && !info.IsDir() {
+					downloadedPaths = append(downloadedPaths, path)
+				}
+				return err
+			}))
+			assert.NotEmpty(t, downloadedPaths)
+			assert.ElementsMatch(t, downloadedPaths, searchedPaths)
+		})
+	}
+}
+
+// Starts a stand-in of Artifactory, which returns the results to every search, and the name of the artifact to every download.
+func createArtifactoryServer(t *testing.T, results []rtutils.ResultItem) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/system/version":
+			_, _ = w.Write([]byte(`{"version":"7.20.0"}`))
+		case r.URL.Path == "/api/search/aql":
+			content, err := json.Marshal(results)
+			assert.NoError(t, err)
+			_, _ = w.Write([]byte(`{"results":` + string(content) + `}`))
+		default:
+			_, _ = w.Write([]byte(path.Base(r.URL.Path)))
+		}
+	}))
+}
@@ -0,0 +1,170 @@
+// Package downloadlock implements the download lock file, which records the artifacts a download spec resolved to,
+// with their sha256 checksums and local paths. Downloading by the lock file downloads exactly the recorded artifacts,
+// and fails if the checksum of any of them in Artifactory, or of any downloaded file, differs from the recorded checksum.
+package downloadlock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/aqlitems"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/downloaditems"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/localfiles"
+	rtutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+const lockVersion = 1
+
+// An artifact recorded in the lock file.
+type Artifact struct {
+	// The path of the artifact in Artifactory, in the <repository name>/<repository path> format.
+	Path   string `json:"path"`
+	Sha256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+	// The local path the artifact is downloaded to. Relative to the working directory, if it's under it.
+	Target string `json:"target"`
+}
+
+type Lock struct {
+	Version   int         `json:"version"`
+	Artifacts []*Artifact `json:"artifacts"`
+}
+
+// Creates a lock of the artifacts searched by the downloaditems package, sorted by their paths.
+func NewLock(items map[string]*downloaditems.Item) (*Lock, error) {
+	wd, err := os.Getwd()
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	lock := &Lock{Version: lockVersion, Artifacts: []*Artifact{}}
+	for localPath, item := range items {
+		if item.Sha256 == "" {
+			return nil, errorutils.CheckError(errors.New("the sha256 checksum of " + item.GetItemRelativePath() + " is missing in Artifactory"))
+		}
+		target := localPath
+		if relPath, err := filepath.Rel(wd, localPath); err == nil && !strings.HasPrefix(relPath, "..") {
+			target = relPath
+		}
+		lock.Artifacts = append(lock.Artifacts, &Artifact{Path: item.GetItemRelativePath(), Sha256: item.Sha256, Size: item.Size, Target: filepath.ToSlash(target)})
+	}
+	sort.Slice(lock.Artifacts, func(i, j int) bool {
+		if lock.Artifacts[i].Path != lock.Artifacts[j].Path {
+			return lock.Artifacts[i].Path < lock.Artifacts[j].Path
+		}
+		return lock.Artifacts[i].Target < lock.Artifacts[j].Target
+	})
+	return lock, nil
+}
+
+func Load(lockPath string) (*Lock, error) {
+	content, err := ioutil.ReadFile(lockPath)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	lock := new(Lock)
+	if err = json.Unmarshal(content, lock); err != nil {
+		return nil, errorutils.CheckError(errors.New("failed parsing the lock file " + lockPath + ": " + err.Error()))
+	}
+	if lock.Version != lockVersion {
+		return nil, errorutils.CheckError(errors.New("the lock file " + lockPath + " has an unsupported version: " + strconv.Itoa(lock.Version)))
+	}
+	return lock, nil
+}
+
+// Writes the lock file to a temporary file, which is then renamed, so that an interruption doesn't leave a partially written lock file.
+func (l *Lock) Save(lockPath string) error {
+	content, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if dir := filepath.Dir(lockPath); dir != "" {
+		if err = os.MkdirAll(dir, 0755); errorutils.CheckError(err) != nil {
+			return err
+		}
+	}
+	tempPath := lockPath + ".tmp"
+	if err = ioutil.WriteFile(tempPath, append(content, '\n'), 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.Rename(tempPath, lockPath))
+}
+
+// Creates a download spec, which downloads each of the recorded artifacts to its recorded local path.
+// The artifacts whose paths include wildcards, or parentheses which would be taken as placeholders of the target, are downloaded by
+// AQL queries of their exact paths.
+func (l *Lock) CreateSpec(explode string, validateSymlinks bool) (*spec.SpecFiles, error) {
+	specFiles := new(spec.SpecFiles)
+	for _, artifact := range l.Artifacts {
+		file := spec.File{
+			Target:           artifact.Target,
+			Flat:             "true",
+			Recursive:        "false",
+			Explode:          explode,
+			ValidateSymlinks: strconv.FormatBool(validateSymlinks),
+		}
+		if strings.ContainsAny(artifact.Path, "*?(") {
+			aqlBody, err := aqlitems.CreatePathAqlBody(artifact.Path)
+			if err != nil {
+				return nil, err
+			}
+			file.Aql = rtutils.Aql{ItemsFind: aqlBody}
+		} else {
+			file.Pattern = artifact.Path
+		}
+		specFiles.Files = append(specFiles.Files, file)
+	}
+	return specFiles, nil
+}
+
+// Verifies that the recorded artifacts exist in Artifactory with their recorded checksums, before they are downloaded.
+// The artifacts are searched by the downloaditems package, using the spec created by CreateSpec.
+func (l *Lock) Verify(items map[string]*downloaditems.Item) error {
+	checksums := make(map[string]string)
+	for _, item := range items {
+		checksums[item.GetItemRelativePath()] = item.Sha256
+	}
+	var mismatches []string
+	for _, artifact := range l.Artifacts {
+		sha256, exists := checksums[artifact.Path]
+		if !exists {
+			mismatches = append(mismatches, artifact.Path+": not found")
+		} else if sha256 != artifact.Sha256 {
+			mismatches = append(mismatches, fmt.Sprintf("%s: expected sha256 %s, but found %s", artifact.Path, artifact.Sha256, sha256))
+		}
+	}
+	if len(mismatches) > 0 {
+		return errorutils.CheckError(errors.New("the following artifacts don't match the lock file:\n" + strings.Join(mismatches, "\n")))
+	}
+	return nil
+}
+
+// Verifies that the downloaded files, at the recorded local paths, have the recorded checksums.
+// Archives which were exploded are removed after their extraction, so they aren't verified.
+func (l *Lock) VerifyDownloaded(explode bool) error {
+	var mismatches []string
+	for _, artifact := range l.Artifacts {
+		if explode && fileutils.IsSupportedArchive(artifact.Target) {
+			continue
+		}
+		_, sha256, err := localfiles.CalcChecksums(filepath.FromSlash(artifact.Target))
+		if err != nil {
+			mismatches = append(mismatches, fmt.Sprintf("%s: failed reading %s: %s", artifact.Path, artifact.Target, err.Error()))
+		} else if sha256 != artifact.Sha256 {
+			mismatches = append(mismatches, fmt.Sprintf("%s: expected sha256 %s, but %s has %s", artifact.Path, artifact.Sha256, artifact.Target, sha256))
+		}
+	}
+	if len(mismatches) > 0 {
+		return errorutils.CheckError(errors.New("the following downloaded files don't match the lock file:\n" + strings.Join(mismatches, "\n")))
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package downloadlock
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/commands/downloaditems"
+	rtutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// The sha256 of the 'a.zip' and 'b.zip' strings.
+const (
+	aSha256 = "d42cf35afe0bcfc26772d92fe13bf3cdaf9b8cf8f0df8eebf569e6a88536e72f"
+	bSha256 = "cf41e189cdc819769f648a9719d25ddfb16e0583d1a9f9391c31378831eb4523"
+)
+
+func createItems(t *testing.T) map[string]*downloaditems.Item {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	aPath := filepath.Join(wd, "out", "a.zip")
+	bPath := filepath.Join(filepath.Dir(wd), "b.zip")
+	return map[string]*downloaditems.Item{
+		bPath: {ResultItem: rtutils.ResultItem{Repo: "repo", Path: ".", Name: "b.zip", Size: 5}, Sha256: bSha256, LocalPath: bPath},
+		aPath: {ResultItem: rtutils.ResultItem{Repo: "repo", Path: "dir", Name: "a.zip", Size: 5}, Sha256: aSha256, LocalPath: aPath},
+	}
+}
+
+func TestNewLock(t *testing.T) {
+	items := createItems(t)
+	lock, err := NewLock(items)
+	assert.NoError(t, err)
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.Equal(t, &Lock{Version: lockVersion, Artifacts: []*Artifact{
+		{Path: "repo/b.zip", Sha256: bSha256, Size: 5, Target: filepath.ToSlash(filepath.Join(filepath.Dir(wd), "b.zip"))},
+		// Local paths under the working directory are recorded relatively to it.
+		{Path: "repo/dir/a.zip", Sha256: aSha256, Size: 5, Target: "out/a.zip"},
+	}}, lock)
+
+	// Artifacts without a sha256 can't be locked.
+	for _, item := range items {
+		item.Sha256 = ""
+	}
+	_, err = NewLock(items)
+	assert.Error(t, err)
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "downloadlock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	lock, err := NewLock(createItems(t))
+	assert.NoError(t, err)
+	lockPath := filepath.Join(tempDir, "locks", "deps.lock.json")
+	assert.NoError(t, lock.Save(lockPath))
+	loaded, err := Load(lockPath)
+	assert.NoError(t, err)
+	assert.Equal(t, lock, loaded)
+
+	assert.NoError(t, ioutil.WriteFile(lockPath, []byte(`{"version":2,"artifacts":[]}`), 0644))
+	_, err = Load(lockPath)
+	assert.Error(t, err)
+}
+
+func TestCreateSpec(t *testing.T) {
+	lock := &Lock{Version: lockVersion, Artifacts: []*Artifact{{Path: "repo/dir/a.zip", Sha256: aSha256, Size: 5, Target: "out/a.zip"}}}
+	specFiles, err := lock.CreateSpec("true", false)
+	assert.NoError(t, err)
+	assert.Len(t, specFiles.Files, 1)
+	file := specFiles.Get(0)
+	assert.Equal(t, "repo/dir/a.zip", file.Pattern)
+	assert.Equal(t, "out/a.zip", file.Target)
+	flat, err := file.IsFlat(false)
+	assert.NoError(t, err)
+	assert.True(t, flat)
+	recursive, err := file.IsRecursive(true)
+	assert.NoError(t, err)
+	assert.False(t, recursive)
+	explode, err := file.IsExplode(false)
+	assert.NoError(t, err)
+	assert.True(t, explode)
+
+	// Paths with wildcards or parentheses are downloaded by their exact paths.
+	lock.Artifacts[0].Path = "repo/dir/a*(1).zip"
+	specFiles, err = lock.CreateSpec("false", false)
+	assert.NoError(t, err)
+	file = specFiles.Get(0)
+	assert.Empty(t, file.Pattern)
+	assert.Equal(t, `{"name":"a*(1).zip","path":"dir","repo":"repo","type":"file"}`, file.Aql.ItemsFind)
+	assert.Equal(t, "out/a.zip", file.Target)
+}
+
+func TestVerify(t *testing.T) {
+	items := createItems(t)
+	lock, err := NewLock(items)
+	assert.NoError(t, err)
+	assert.NoError(t, lock.Verify(items))
+
+	// An artifact whose checksum changed in Artifactory.
+	for _, item := range items {
+		if item.Name == "a.zip" {
+			item.Sha256 = bSha256
+		}
+	}
+	err = lock.Verify(items)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "repo/dir/a.zip: expected sha256 "+aSha256+", but found "+bSha256)
+	}
+
+	// An artifact which was removed from Artifactory.
+	err = lock.Verify(map[string]*downloaditems.Item{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "repo/b.zip: not found")
+	}
+}
+
+func TestVerifyDownloaded(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "downloadlock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	aPath := filepath.Join(tempDir, "a.zip")
+	assert.NoError(t, ioutil.WriteFile(aPath, []byte("a.zip"), 0644))
+	lock := &Lock{Version: lockVersion, Artifacts: []*Artifact{{Path: "repo/dir/a.zip", Sha256: aSha256, Size: 5, Target: filepath.ToSlash(aPath)}}}
+	assert.NoError(t, lock.VerifyDownloaded(false))
+
+	// A file which changed during the download.
+	assert.NoError(t, ioutil.WriteFile(aPath, []byte("b.zip"), 0644))
+	err = lock.VerifyDownloaded(false)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "repo/dir/a.zip: expected sha256 "+aSha256+", but "+filepath.ToSlash(aPath)+" has "+bSha256)
+	}
+
+	// A file which wasn't downloaded.
+	assert.NoError(t, os.Remove(aPath))
+	assert.Error(t, lock.VerifyDownloaded(false))
+	// Exploded archives are removed after their extraction.
+	assert.NoError(t, lock.VerifyDownloaded(true))
+}
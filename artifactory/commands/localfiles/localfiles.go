@@ -0,0 +1,131 @@
+// Package localfiles resolves the local files of an upload file spec, and the target paths they're uploaded to,
+// the same way the upload command of jfrog-client-go does, without uploading them.
+// The upload command doesn't export its collection of the files, so its walk of the pattern and its replacement of the placeholders
+// are repeated here, using the exported fspatterns utils, and should be kept in line with the jfrog-client-go version in use.
+package localfiles
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/fspatterns"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// A local file, and the path in Artifactory the upload command uploads it to.
+type LocalFile struct {
+	LocalPath  string
+	TargetPath string
+}
+
+// Collects the local files matching the file spec, the same way the upload command does.
+// Directories, archives and preserved symlinks aren't supported, and are ignored.
+func Collect(file *spec.File) ([]LocalFile, error) {
+	uploadParams, err := getUploadParams(file)
+	if err != nil {
+		return nil, err
+	}
+	target := uploadParams.GetTarget()
+	if !strings.Contains(target, "/") {
+		target += "/"
+	}
+	patternType := uploadParams.GetPatternType()
+	pattern := clientutils.ReplaceTildeWithUserHome(uploadParams.GetPattern())
+	rootPath, err := fspatterns.GetRootPath(pattern, target, patternType, false)
+	if err != nil {
+		return nil, err
+	}
+	isDir, err := fileutils.IsDirExists(rootPath, false)
+	if err != nil {
+		return nil, err
+	}
+	if !isDir {
+		artifact, err := fspatterns.GetSingleFileToUpload(rootPath, target, uploadParams.IsFlat(), false)
+		if err != nil {
+			return nil, err
+		}
+		return []LocalFile{{LocalPath: artifact.LocalPath, TargetPath: artifact.TargetPath}}, nil
+	}
+
+	uploadParams.SetPattern(clientutils.PrepareLocalPathForUpload(pattern, patternType))
+	excludePathPattern := fspatterns.PrepareExcludePathPattern(uploadParams)
+	patternRegex, err := regexp.Compile(uploadParams.GetPattern())
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	paths, err := fspatterns.GetPaths(rootPath, uploadParams.IsRecursive(), false, false)
+	if err != nil {
+		return nil, err
+	}
+	var files []LocalFile
+	for _, path := range paths {
+		matches, isDir, _, err := fspatterns.PrepareAndFilterPaths(path, excludePathPattern, false, false, patternRegex)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 || isDir {
+			continue
+		}
+		targetPath, err := getUploadTarget(path, target, matches, uploadParams.IsFlat())
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, LocalFile{LocalPath: path, TargetPath: targetPath})
+	}
+	return files, nil
+}
+
+// Returns the upload parameters of the file spec, with the defaults of the upload command.
+func getUploadParams(file *spec.File) (uploadParams services.UploadParams, err error) {
+	uploadParams = services.NewUploadParams()
+	if uploadParams.ArtifactoryCommonParams, err = file.ToArtifactoryCommonParams(); err != nil {
+		return
+	}
+	if uploadParams.Recursive, err = file.IsRecursive(true); err != nil {
+		return
+	}
+	if uploadParams.Regexp, err = file.IsRegexp(false); err != nil {
+		return
+	}
+	if uploadParams.Ant, err = file.IsAnt(false); err != nil {
+		return
+	}
+	uploadParams.Flat, err = file.IsFlat(true)
+	return
+}
+
+// Returns the target path the upload command uploads the local file to.
+// The placeholders of the target are replaced by the groups the pattern matched, like the upload command does.
+// The flat option and the symlinks are then handled by jfrog-client-go, the same way it handles a single file to upload.
+func getUploadTarget(localPath, target string, groups []string, flat bool) (string, error) {
+	for i := 1; i < len(groups); i++ {
+		target = strings.Replace(target, "{"+strconv.Itoa(i)+"}", strings.Replace(groups[i], "\\", "/", -1), -1)
+	}
+	artifact, err := fspatterns.GetSingleFileToUpload(localPath, target, flat, false)
+	return artifact.TargetPath, err
+}
+
+// Calculates the sha1 and the sha256 checksums of the file, reading it once.
+// The checksum utils of jfrog-client-go don't calculate the sha256 checksum.
+func CalcChecksums(localPath string) (sha1Checksum, sha256Checksum string, err error) {
+	file, err := os.Open(localPath)
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	defer file.Close()
+	sha1Hash, sha256Hash := sha1.New(), sha256.New()
+	if _, err = io.Copy(io.MultiWriter(sha1Hash, sha256Hash), file); errorutils.CheckError(err) != nil {
+		return
+	}
+	return hex.EncodeToString(sha1Hash.Sum(nil)), hex.EncodeToString(sha256Hash.Sum(nil)), nil
+}
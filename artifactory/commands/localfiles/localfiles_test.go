@@ -0,0 +1,74 @@
+package localfiles
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollect(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "diff")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	for _, path := range []string{"a.zip", "b.txt", filepath.Join("sub", "c.zip")} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(tempDir, path)), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(tempDir, path), []byte(path), 0644))
+	}
+	pattern := filepath.ToSlash(tempDir) + "/*.zip"
+
+	// Flat, like the upload command by default.
+	files, err := Collect(spec.NewBuilder().Pattern(pattern).Target("repo/dir/").Recursive(true).Flat(true).BuildSpec().Get(0))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []LocalFile{
+		{LocalPath: filepath.Join(tempDir, "a.zip"), TargetPath: "repo/dir/a.zip"},
+		{LocalPath: filepath.Join(tempDir, "sub", "c.zip"), TargetPath: "repo/dir/c.zip"},
+	}, files)
+
+	// Placeholders.
+	placeholdersPattern := filepath.ToSlash(tempDir) + "/(*).zip"
+	files, err = Collect(spec.NewBuilder().Pattern(placeholdersPattern).Target("repo/{1}/").Recursive(false).Flat(true).BuildSpec().Get(0))
+	assert.NoError(t, err)
+	assert.Equal(t, []LocalFile{{LocalPath: filepath.Join(tempDir, "a.zip"), TargetPath: "repo/a/a.zip"}}, files)
+
+	// A single file.
+	files, err = Collect(spec.NewBuilder().Pattern(filepath.Join(tempDir, "b.txt")).Target("repo/c.txt").BuildSpec().Get(0))
+	assert.NoError(t, err)
+	assert.Equal(t, []LocalFile{{LocalPath: filepath.Join(tempDir, "b.txt"), TargetPath: "repo/c.txt"}}, files)
+}
+
+func TestGetUploadTarget(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "diff")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	localPath := filepath.Join(tempDir, "a.zip")
+	assert.NoError(t, ioutil.WriteFile(localPath, []byte("a"), 0644))
+
+	target, err := getUploadTarget(localPath, "repo/{1}/", []string{localPath, "a"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "repo/a/a.zip", target)
+	target, err = getUploadTarget(localPath, "repo/b.zip", []string{localPath}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "repo/b.zip", target)
+	target, err = getUploadTarget(localPath, "repo/", []string{localPath}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "repo/"+strings.TrimPrefix(filepath.ToSlash(localPath), "/"), target)
+}
+
+func TestCalcChecksums(t *testing.T) {
+	tempFile, err := ioutil.TempFile("", "localfiles")
+	assert.NoError(t, err)
+	defer os.Remove(tempFile.Name())
+	_, err = tempFile.WriteString("a.zip")
+	assert.NoError(t, err)
+	assert.NoError(t, tempFile.Close())
+
+	sha1, sha256, err := CalcChecksums(tempFile.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, "8418018ba2e7387a0805f6a2d7c646b2b6c14f14", sha1)
+	assert.Equal(t, "d42cf35afe0bcfc26772d92fe13bf3cdaf9b8cf8f0df8eebf569e6a88536e72f", sha256)
+}
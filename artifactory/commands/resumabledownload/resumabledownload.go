@@ -0,0 +1,321 @@
+// Package resumabledownload downloads the artifacts which the download command would download in concurrent ranges,
+// before the download command runs. The downloaded ranges are recorded in a journal next to the partially downloaded file,
+// so that when the download is interrupted, running the command again downloads only the missing ranges.
+// The completed file is verified against its sha256 checksum and renamed to its local path,
+// where the download command then finds it with the expected checksum and doesn't download it again.
+// The artifacts are downloaded this way only if the --resumable option of the download command is set.
+package resumabledownload
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/downloaditems"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	rtutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The suffix of the partially downloaded file, next to the local path of the artifact.
+	PartialSuffix = ".jfrog-download"
+	// The suffix of the journal, which records the downloaded ranges of the partially downloaded file.
+	JournalSuffix  = PartialSuffix + ".json"
+	journalVersion = 1
+	// Large files are downloaded in ranges of up to 16MB, so that an interrupted download loses at most a range per connection.
+	maxRangeSize = 16 * 1024 * 1024
+)
+
+var sha256Regex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// The downloaded ranges of a partially downloaded file.
+type journal struct {
+	Version   int    `json:"version"`
+	Sha256    string `json:"sha256"`
+	Size      int64  `json:"size"`
+	RangeSize int64  `json:"rangeSize"`
+	// The indexes of the downloaded ranges.
+	Completed []int `json:"completed"`
+}
+
+func newJournal(item *downloaditems.Item, splitCount int) *journal {
+	rangeSize := (item.Size + int64(splitCount) - 1) / int64(splitCount)
+	if rangeSize > maxRangeSize {
+		rangeSize = maxRangeSize
+	}
+	return &journal{Version: journalVersion, Sha256: item.Sha256, Size: item.Size, RangeSize: rangeSize}
+}
+
+func (j *journal) rangesCount() int {
+	return int((j.Size + j.RangeSize - 1) / j.RangeSize)
+}
+
+// Returns the first and last bytes of the range.
+func (j *journal) getRange(index int) (start, end int64) {
+	start = int64(index) * j.RangeSize
+	end = start + j.RangeSize - 1
+	if end >= j.Size {
+		end = j.Size - 1
+	}
+	return
+}
+
+// Returns the indexes of the ranges which weren't downloaded yet.
+func (j *journal) missingRanges() []int {
+	completed := make(map[int]bool)
+	for _, index := range j.Completed {
+		completed[index] = true
+	}
+	var missing []int
+	for index := 0; index < j.rangesCount(); index++ {
+		if !completed[index] {
+			missing = append(missing, index)
+		}
+	}
+	return missing
+}
+
+func (j *journal) completedSize() (size int64) {
+	for _, index := range j.Completed {
+		start, end := j.getRange(index)
+		size += end - start + 1
+	}
+	return
+}
+
+// Reads the journal. Returns nil if it doesn't exist, or if it doesn't match the artifact.
+func loadJournal(journalPath string, item *downloaditems.Item) (*journal, error) {
+	content, err := ioutil.ReadFile(journalPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	j := new(journal)
+	if err = json.Unmarshal(content, j); err != nil {
+		log.Debug("Ignoring the invalid download journal " + journalPath + ": " + err.Error())
+		return nil, nil
+	}
+	if j.Version != journalVersion || j.Sha256 != item.Sha256 || j.Size != item.Size || j.RangeSize <= 0 {
+		log.Debug("The download journal " + journalPath + " doesn't match the artifact in Artifactory, which was probably modified.")
+		return nil, nil
+	}
+	return j, nil
+}
+
+// Writes the journal to a temporary file, which is then renamed, so that an interruption doesn't leave a partially written journal.
+func (j *journal) save(journalPath string) error {
+	content, err := json.Marshal(j)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	tempPath := journalPath + ".tmp"
+	if err = ioutil.WriteFile(tempPath, content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.Rename(tempPath, journalPath))
+}
+
+type Downloader struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+	splitCount      int
+	// The minimal size in bytes of the artifacts which are downloaded in ranges.
+	minSplitSize int64
+}
+
+// Returns nil if the download configuration doesn't download in concurrent ranges.
+func New(serverDetails *config.ServerDetails, configuration *utils.DownloadConfiguration, retries int) (*Downloader, error) {
+	if configuration.SplitCount <= 0 || configuration.MinSplitSize < 0 {
+		return nil, nil
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, retries, false)
+	if err != nil {
+		return nil, err
+	}
+	return &Downloader{servicesManager: servicesManager, splitCount: configuration.SplitCount, minSplitSize: configuration.MinSplitSize * 1000}, nil
+}
+
+// Downloads the artifacts which are large enough to be downloaded in concurrent ranges, and resumes their interrupted downloads.
+// The artifacts are searched by the downloaditems package. An artifact which fails to download is reported as a warning,
+// and left to the download command. Its downloaded ranges are kept, so that the next run resumes its download.
+func (d *Downloader) Download(items map[string]*downloaditems.Item) {
+	var localPaths []string
+	for localPath, item := range items {
+		if item.Size > 0 && item.Size >= d.minSplitSize && sha256Regex.MatchString(item.Sha256) {
+			localPaths = append(localPaths, localPath)
+		}
+	}
+	sort.Strings(localPaths)
+	for _, localPath := range localPaths {
+		if err := d.downloadItem(items[localPath]); err != nil {
+			log.Warn("Failed resuming the download of " + items[localPath].GetItemRelativePath() + ": " + err.Error())
+		}
+	}
+}
+
+func (d *Downloader) downloadItem(item *downloaditems.Item) error {
+	partialPath := item.LocalPath + PartialSuffix
+	journalPath := item.LocalPath + JournalSuffix
+	// The download command doesn't download a local file which is equal to the artifact.
+	equal, err := fileutils.IsEqualToLocalFile(item.LocalPath, item.Actual_Md5, item.Actual_Sha1)
+	if err != nil {
+		return err
+	}
+	if equal {
+		return removePartial(partialPath, journalPath)
+	}
+	j, err := loadJournal(journalPath, item)
+	if err != nil {
+		return err
+	}
+	if j == nil || !fileutils.IsPathExists(partialPath, false) {
+		j = newJournal(item, d.splitCount)
+	} else {
+		log.Info(fmt.Sprintf("Resuming the download of %s: %d of %d bytes were already downloaded.", item.GetItemRelativePath(), j.completedSize(), j.Size))
+	}
+	if err = os.MkdirAll(filepath.Dir(item.LocalPath), 0755); errorutils.CheckError(err) != nil {
+		return err
+	}
+	file, err := os.OpenFile(partialPath, os.O_RDWR|os.O_CREATE, 0644)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	err = file.Truncate(j.Size)
+	if errorutils.CheckError(err) == nil {
+		err = d.downloadRanges(item, file, j, journalPath)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = errorutils.CheckError(closeErr)
+	}
+	if err != nil {
+		return err
+	}
+	return verifyAndRename(item, partialPath, journalPath)
+}
+
+// Downloads the missing ranges of the file, in concurrent connections, and records each downloaded range in the journal.
+func (d *Downloader) downloadRanges(item *downloaditems.Item, file *os.File, j *journal, journalPath string) error {
+	serviceDetails := d.servicesManager.GetConfig().GetServiceDetails()
+	downloadUrl, err := rtutils.BuildArtifactoryUrl(serviceDetails.GetUrl(), item.GetItemRelativePath(), make(map[string]string))
+	if err != nil {
+		return err
+	}
+	ranges := make(chan int)
+	var mutex sync.Mutex
+	var firstErr error
+	var wg sync.WaitGroup
+	for i := 0; i < d.splitCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range ranges {
+				start, end := j.getRange(index)
+				err := d.downloadRange(downloadUrl, file, start, end)
+				if err == nil {
+					// The range is recorded only after its content is persisted.
+					err = errorutils.CheckError(file.Sync())
+				}
+				mutex.Lock()
+				if err == nil {
+					j.Completed = append(j.Completed, index)
+					err = j.save(journalPath)
+				}
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	for _, index := range j.missingRanges() {
+		mutex.Lock()
+		failed := firstErr != nil
+		mutex.Unlock()
+		if failed {
+			break
+		}
+		ranges <- index
+	}
+	close(ranges)
+	wg.Wait()
+	return firstErr
+}
+
+func (d *Downloader) downloadRange(downloadUrl string, file *os.File, start, end int64) error {
+	httpClientDetails := d.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpClientDetails.Headers["Range"] = "bytes=" + strconv.FormatInt(start, 10) + "-" + strconv.FormatInt(end, 10)
+	resp, body, _, err := d.servicesManager.Client().SendGet(downloadUrl, true, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		return errorutils.CheckError(errors.New("expected a partial content response to a range request, but received: " + resp.Status))
+	}
+	if int64(len(body)) != end-start+1 {
+		return errorutils.CheckError(fmt.Errorf("expected %d bytes in the range %d-%d, but received %d bytes", end-start+1, start, end, len(body)))
+	}
+	_, err = file.WriteAt(body, start)
+	return errorutils.CheckError(err)
+}
+
+// Verifies the downloaded file against the sha256 checksum of the artifact, and renames it to the local path of the artifact.
+// A file which doesn't match the checksum is removed, with its journal, so that the next run downloads it again.
+func verifyAndRename(item *downloaditems.Item, partialPath, journalPath string) error {
+	actualSha256, err := calcSha256(partialPath)
+	if err != nil {
+		return err
+	}
+	if actualSha256 != item.Sha256 {
+		if err = removePartial(partialPath, journalPath); err != nil {
+			return err
+		}
+		return errorutils.CheckError(errors.New("the sha256 checksum of the downloaded file " + actualSha256 + " doesn't match the checksum in Artifactory " + item.Sha256))
+	}
+	if err = os.Remove(item.LocalPath); err != nil && !os.IsNotExist(err) {
+		return errorutils.CheckError(err)
+	}
+	if err = os.Rename(partialPath, item.LocalPath); errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Debug("Downloaded", item.GetItemRelativePath(), "in ranges to", item.LocalPath)
+	return removePartial(partialPath, journalPath)
+}
+
+func removePartial(partialPath, journalPath string) error {
+	for _, path := range []string{partialPath, journalPath} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
+func calcSha256(localPath string) (string, error) {
+	file, err := os.Open(localPath)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,146 @@
+package resumabledownload
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/downloaditems"
+	rtutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+// Serves the content at /repo/dir/file.bin, and records the requested ranges.
+func createServer(content []byte) (*httptest.Server, func() []string) {
+	var mutex sync.Mutex
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		ranges = append(ranges, r.Header.Get("Range"))
+		mutex.Unlock()
+		http.ServeContent(w, r, "file.bin", time.Now(), bytes.NewReader(content))
+	}))
+	return server, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return ranges
+	}
+}
+
+func createItem(content []byte, localPath string) *downloaditems.Item {
+	md5Sum, sha1Sum, sha256Sum := md5.Sum(content), sha1.Sum(content), sha256.Sum256(content)
+	return &downloaditems.Item{
+		ResultItem: rtutils.ResultItem{Repo: "repo", Path: "dir", Name: "file.bin", Size: int64(len(content)),
+			Actual_Md5: hex.EncodeToString(md5Sum[:]), Actual_Sha1: hex.EncodeToString(sha1Sum[:])},
+		Sha256:    hex.EncodeToString(sha256Sum[:]),
+		LocalPath: localPath,
+	}
+}
+
+func createDownloader(t *testing.T, serverUrl string) *Downloader {
+	log.SetLogger(log.NewLogger(log.INFO, nil))
+	downloader, err := New(&config.ServerDetails{ArtifactoryUrl: serverUrl + "/"}, &utils.DownloadConfiguration{SplitCount: 2, MinSplitSize: 0}, 0)
+	assert.NoError(t, err)
+	return downloader
+}
+
+func TestNew(t *testing.T) {
+	downloader, err := New(&config.ServerDetails{ArtifactoryUrl: "http://localhost/"}, &utils.DownloadConfiguration{SplitCount: 0, MinSplitSize: 5120}, 0)
+	assert.NoError(t, err)
+	assert.Nil(t, downloader)
+	downloader, err = New(&config.ServerDetails{ArtifactoryUrl: "http://localhost/"}, &utils.DownloadConfiguration{SplitCount: 3, MinSplitSize: -1}, 0)
+	assert.NoError(t, err)
+	assert.Nil(t, downloader)
+}
+
+func TestJournalRanges(t *testing.T) {
+	j := newJournal(&downloaditems.Item{ResultItem: rtutils.ResultItem{Size: 10}}, 3)
+	assert.Equal(t, int64(4), j.RangeSize)
+	assert.Equal(t, 3, j.rangesCount())
+	start, end := j.getRange(2)
+	assert.Equal(t, int64(8), start)
+	assert.Equal(t, int64(9), end)
+	j.Completed = []int{2, 0}
+	assert.Equal(t, []int{1}, j.missingRanges())
+	assert.Equal(t, int64(6), j.completedSize())
+
+	// Large files are downloaded in ranges of up to 16MB.
+	j = newJournal(&downloaditems.Item{ResultItem: rtutils.ResultItem{Size: 100 * 1024 * 1024}}, 3)
+	assert.Equal(t, int64(maxRangeSize), j.RangeSize)
+	assert.Equal(t, 7, j.rangesCount())
+}
+
+func TestResumeDownload(t *testing.T) {
+	content := []byte(strings.Repeat("0123456789", 10))
+	server, getRanges := createServer(content)
+	defer server.Close()
+	tempDir, err := ioutil.TempDir("", "resumabledownload")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	localPath := filepath.Join(tempDir, "out", "file.bin")
+	item := createItem(content, localPath)
+
+	// The first range was downloaded before the previous download was interrupted.
+	assert.NoError(t, os.MkdirAll(filepath.Dir(localPath), 0755))
+	partial := make([]byte, len(content))
+	copy(partial, content[:50])
+	assert.NoError(t, ioutil.WriteFile(localPath+PartialSuffix, partial, 0644))
+	j := newJournal(item, 2)
+	j.Completed = []int{0}
+	assert.NoError(t, j.save(localPath+JournalSuffix))
+
+	createDownloader(t, server.URL).Download(map[string]*downloaditems.Item{localPath: item})
+	assert.Equal(t, []string{"bytes=50-99"}, getRanges())
+	downloaded, err := ioutil.ReadFile(localPath)
+	assert.NoError(t, err)
+	assert.Equal(t, content, downloaded)
+	assert.NoFileExists(t, localPath+PartialSuffix)
+	assert.NoFileExists(t, localPath+JournalSuffix)
+
+	// A local file which is equal to the artifact isn't downloaded again.
+	createDownloader(t, server.URL).Download(map[string]*downloaditems.Item{localPath: item})
+	assert.Len(t, getRanges(), 1)
+}
+
+func TestDownloadChecksumMismatch(t *testing.T) {
+	content := []byte(strings.Repeat("0123456789", 10))
+	server, getRanges := createServer(content)
+	defer server.Close()
+	tempDir, err := ioutil.TempDir("", "resumabledownload")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	localPath := filepath.Join(tempDir, "file.bin")
+	item := createItem(content, localPath)
+
+	// The journal of a different artifact is discarded, and all the ranges are downloaded.
+	assert.NoError(t, ioutil.WriteFile(localPath+PartialSuffix, make([]byte, len(content)), 0644))
+	j := newJournal(item, 2)
+	j.Sha256 = strings.Repeat("0", 64)
+	j.Completed = []int{0}
+	assert.NoError(t, j.save(localPath+JournalSuffix))
+	createDownloader(t, server.URL).Download(map[string]*downloaditems.Item{localPath: item})
+	assert.ElementsMatch(t, []string{"bytes=0-49", "bytes=50-99"}, getRanges())
+	assert.FileExists(t, localPath)
+
+	// A downloaded file which doesn't match the checksum is removed.
+	assert.NoError(t, os.Remove(localPath))
+	item.Sha256 = strings.Repeat("0", 64)
+	createDownloader(t, server.URL).Download(map[string]*downloaditems.Item{localPath: item})
+	assert.NoFileExists(t, localPath)
+	assert.NoFileExists(t, localPath+PartialSuffix)
+	assert.NoFileExists(t, localPath+JournalSuffix)
+}
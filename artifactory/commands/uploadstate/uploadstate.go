@@ -0,0 +1,370 @@
+// Package uploadstate implements the state file of the upload command. The state file records the size, the modification time
+// and the sha256 checksum of each uploaded file, per Artifactory server and target path. When the upload command runs again
+// with the same state file, the files whose size and modification time haven't changed are skipped, without any request to Artifactory.
+package uploadstate
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/utils/ioutils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/aqlitems"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/localfiles"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	rtutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const stateVersion = 1
+
+// The maximum number of file specs of the filtered spec, each of which uploads a single changed file.
+// If more files were changed, the original spec is uploaded instead.
+var maxFilteredFileSpecs = 1000
+
+// The characters escaped by regexp.QuoteMeta, except for the dot, which may be left in the directories the upload command starts from.
+const regexpSpecialChars = `\+*?()|[]{}^$`
+
+// The uploaded file, as recorded in the state file.
+type FileState struct {
+	LocalPath string    `json:"localPath"`
+	Size      int64     `json:"size"`
+	ModTime   time.Time `json:"modTime"`
+	Sha256    string    `json:"sha256"`
+	// The last time the file was uploaded, or found in Artifactory with the same checksum.
+	Verified time.Time `json:"verified"`
+}
+
+// The content of the state file.
+type State struct {
+	Version int `json:"version"`
+	// The uploaded files by the URL of the Artifactory server, and their target paths.
+	Servers map[string]map[string]*FileState `json:"servers"`
+}
+
+// Keeps the state of the uploaded files, filters the unchanged files out of the upload spec,
+// and records the files uploaded by the upload command.
+type Tracker struct {
+	path      string
+	serverUrl string
+	// If set, the unchanged files which weren't verified in this duration are searched in Artifactory,
+	// and uploaded again if they're missing or their checksum differs.
+	verifyInterval *time.Duration
+	state          *State
+	// The files to upload. A local file may be uploaded to several targets, by different file specs.
+	pending map[pendingKey]*pendingFile
+	skipped int
+}
+
+// Identifies a file which is about to be uploaded by its local path and the URL it's uploaded to,
+// which are the source and the target paths of the upload command result.
+type pendingKey struct {
+	localPath string
+	targetUrl string
+}
+
+// A local file matched by a file spec of the upload spec.
+type matchedFile struct {
+	file      *spec.File
+	localFile localfiles.LocalFile
+	key       pendingKey
+}
+
+// A file which is about to be uploaded, with its metadata when the upload started.
+type pendingFile struct {
+	targetPath string
+	info       os.FileInfo
+}
+
+// Loads the state file. If the state file doesn't exist, the state is empty, and the file is created when the state is saved.
+func NewTracker(path, serverUrl string, verifyInterval *time.Duration) (*Tracker, error) {
+	state, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+	serverUrl = clientutils.AddTrailingSlashIfNeeded(serverUrl)
+	if state.Servers[serverUrl] == nil {
+		state.Servers[serverUrl] = make(map[string]*FileState)
+	}
+	return &Tracker{path: path, serverUrl: serverUrl, verifyInterval: verifyInterval, state: state, pending: make(map[pendingKey]*pendingFile)}, nil
+}
+
+func load(path string) (*State, error) {
+	state := &State{Version: stateVersion, Servers: make(map[string]map[string]*FileState)}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, errorutils.CheckError(errors.New("failed reading the upload state file " + path + ": " + err.Error()))
+	}
+	if state.Version != stateVersion {
+		return nil, errorutils.CheckError(errors.New("the upload state file " + path + " was written by an unsupported version of JFrog CLI"))
+	}
+	if state.Servers == nil {
+		state.Servers = make(map[string]map[string]*FileState)
+	}
+	return state, nil
+}
+
+// Returns the number of unchanged files, which were filtered out of the upload spec.
+func (t *Tracker) Skipped() int {
+	return t.skipped
+}
+
+// Returns a spec of the files which were changed since they were last uploaded, or weren't uploaded yet.
+// Each of the files is set in a separate file spec, with the target path the original file spec uploads it to.
+// If more than maxFilteredFileSpecs files were changed, the original spec is returned, and all of its files are uploaded.
+// Returns nil if all the files are unchanged.
+// The unchanged files which should be verified are searched in Artifactory, in the given server.
+func (t *Tracker) FilterSpec(uploadSpec *spec.SpecFiles, serverDetails *config.ServerDetails, retries int) (*spec.SpecFiles, error) {
+	files := t.state.Servers[t.serverUrl]
+	var changed, toVerify, unchanged []*matchedFile
+	for i := 0; i < len(uploadSpec.Files); i++ {
+		file := uploadSpec.Get(i)
+		if err := validateFile(file); err != nil {
+			return nil, err
+		}
+		localFiles, err := localfiles.Collect(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, localFile := range localFiles {
+			info, err := os.Stat(localFile.LocalPath)
+			if errorutils.CheckError(err) != nil {
+				return nil, err
+			}
+			key, err := t.newPendingKey(localFile.LocalPath, localFile.TargetPath)
+			if err != nil {
+				return nil, err
+			}
+			t.pending[key] = &pendingFile{targetPath: localFile.TargetPath, info: info}
+			matched := &matchedFile{file: file, localFile: localFile, key: key}
+			fileState := files[localFile.TargetPath]
+			switch {
+			case !isUnchanged(fileState, localFile, info):
+				changed = append(changed, matched)
+			case t.shouldVerify(fileState):
+				toVerify = append(toVerify, matched)
+			default:
+				unchanged = append(unchanged, matched)
+			}
+		}
+	}
+	if len(toVerify) > 0 {
+		servicesManager, err := utils.CreateServiceManager(serverDetails, retries, false)
+		if err != nil {
+			return nil, err
+		}
+		verifiedChanged, verifiedUnchanged, err := t.verify(toVerify, servicesManager)
+		if err != nil {
+			return nil, err
+		}
+		changed = append(changed, verifiedChanged...)
+		unchanged = append(unchanged, verifiedUnchanged...)
+	}
+	if len(changed) > maxFilteredFileSpecs {
+		log.Info(len(changed), "files were changed since they were last uploaded. Uploading all the files, without skipping the unchanged ones.")
+		return uploadSpec, nil
+	}
+	for _, matched := range unchanged {
+		delete(t.pending, matched.key)
+	}
+	t.skipped = len(unchanged)
+	if t.skipped > 0 {
+		log.Info("Skipped", t.skipped, "unchanged files, according to the upload state file.")
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	filteredSpec := new(spec.SpecFiles)
+	for _, matched := range changed {
+		filteredSpec.Files = append(filteredSpec.Files, createFileSpec(matched.file, matched.localFile))
+	}
+	return filteredSpec, nil
+}
+
+func (t *Tracker) newPendingKey(localPath, targetPath string) (pendingKey, error) {
+	targetUrl, err := rtutils.BuildArtifactoryUrl(t.serverUrl, targetPath, make(map[string]string))
+	return pendingKey{localPath: localPath, targetUrl: targetUrl}, err
+}
+
+// Files which aren't uploaded as they are can't be skipped, since the state of the target can't be deduced from them.
+func validateFile(file *spec.File) error {
+	for _, option := range []struct {
+		name  string
+		isSet func(bool) (bool, error)
+	}{
+		{"explode", file.IsExplode},
+		{"include-dirs", file.IsIncludeDirs},
+		{"symlinks", file.IsSymlinks},
+	} {
+		isSet, err := option.isSet(false)
+		if err != nil {
+			return err
+		}
+		if isSet {
+			return errorutils.CheckError(errors.New("the upload state file can't be used with the " + option.name + " option"))
+		}
+	}
+	if file.Archive != "" {
+		return errorutils.CheckError(errors.New("the upload state file can't be used with the archive option"))
+	}
+	return nil
+}
+
+// Returns a file spec which uploads only the local file, with the same properties as the original file spec.
+func createFileSpec(file *spec.File, localFile localfiles.LocalFile) spec.File {
+	fileSpec := *file
+	pattern, isRegexp, recursive := getExactPattern(localFile.LocalPath, localFile.TargetPath)
+	fileSpec.Pattern = pattern
+	fileSpec.Target = localFile.TargetPath
+	fileSpec.Exclusions = nil
+	fileSpec.ExcludePatterns = nil
+	fileSpec.Recursive = strconv.FormatBool(recursive)
+	fileSpec.Flat = "true"
+	fileSpec.Regexp = strconv.FormatBool(isRegexp)
+	fileSpec.Ant = "false"
+	return fileSpec
+}
+
+// Returns a pattern which matches only the local path, and whether it's a regular expression which should be matched recursively.
+// The local path is the pattern itself, unless it includes wildcards, or parentheses which would be taken as placeholders of the target.
+// Such a path is escaped as a regular expression. The upload command searches a regular expression from the directory
+// before its first parentheses, so the escaped part starts with an empty group, after the directories without special characters.
+func getExactPattern(localPath, targetPath string) (pattern string, isRegexp, recursive bool) {
+	if !strings.Contains(localPath, "*") && !(strings.Contains(localPath, "(") && strings.Contains(targetPath, "{")) {
+		if coreutils.IsWindows() {
+			return ioutils.DoubleWinPathSeparator(localPath), false, false
+		}
+		return localPath, false, false
+	}
+	sections := strings.Split(localPath, string(filepath.Separator))
+	escaped := false
+	for i, section := range sections {
+		if !escaped && !strings.ContainsAny(section, regexpSpecialChars) {
+			continue
+		}
+		if !escaped {
+			escaped = true
+			recursive = i < len(sections)-1
+			sections[i] = "(?:)" + regexp.QuoteMeta(section)
+			continue
+		}
+		sections[i] = regexp.QuoteMeta(section)
+	}
+	return strings.Join(sections, regexp.QuoteMeta(string(filepath.Separator))) + "$", true, recursive
+}
+
+func isUnchanged(fileState *FileState, localFile localfiles.LocalFile, info os.FileInfo) bool {
+	return fileState != nil && fileState.LocalPath == localFile.LocalPath && fileState.Size == info.Size() && fileState.ModTime.Equal(info.ModTime())
+}
+
+func (t *Tracker) shouldVerify(fileState *FileState) bool {
+	return t.verifyInterval != nil && time.Since(fileState.Verified) >= *t.verifyInterval
+}
+
+// Searches the unchanged files in Artifactory. Returns the files which are missing, or whose checksum differs from the recorded one,
+// and the files which were found with the recorded checksum.
+func (t *Tracker) verify(toVerify []*matchedFile, servicesManager artifactory.ArtifactoryServicesManager) (changed, unchanged []*matchedFile, err error) {
+	var targetPaths []string
+	for _, matched := range toVerify {
+		targetPaths = append(targetPaths, matched.localFile.TargetPath)
+	}
+	checksums, err := aqlitems.SearchChecksums(servicesManager, targetPaths)
+	if err != nil {
+		return nil, nil, err
+	}
+	files := t.state.Servers[t.serverUrl]
+	now := time.Now()
+	for _, matched := range toVerify {
+		targetPath := matched.localFile.TargetPath
+		fileState := files[targetPath]
+		if checksum, exists := checksums[targetPath]; exists && checksum == fileState.Sha256 {
+			fileState.Verified = now
+			unchanged = append(unchanged, matched)
+			continue
+		}
+		log.Debug("The state of", targetPath, "differs from the upload state file. Uploading it again.")
+		changed = append(changed, matched)
+	}
+	return changed, unchanged, nil
+}
+
+// Records the files which were uploaded successfully, as listed by the reader of the upload command result.
+// The reader is reset, so it can be read again.
+func (t *Tracker) Update(reader *content.ContentReader) error {
+	if reader == nil {
+		return nil
+	}
+	defer reader.Reset()
+	files := t.state.Servers[t.serverUrl]
+	now := time.Now()
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		key := pendingKey{localPath: transferDetails.SourcePath, targetUrl: transferDetails.TargetPath}
+		pending, exists := t.pending[key]
+		if !exists {
+			continue
+		}
+		sha256 := transferDetails.Sha256
+		if sha256 == "" {
+			var err error
+			if _, sha256, err = localfiles.CalcChecksums(transferDetails.SourcePath); err != nil {
+				return err
+			}
+		}
+		files[pending.targetPath] = &FileState{
+			LocalPath: transferDetails.SourcePath,
+			Size:      pending.info.Size(),
+			ModTime:   pending.info.ModTime(),
+			Sha256:    sha256,
+			Verified:  now,
+		}
+		delete(t.pending, key)
+	}
+	return reader.GetError()
+}
+
+// Writes the state file. The state file is replaced atomically, so that it isn't corrupted if the command is interrupted.
+func (t *Tracker) Save() error {
+	data, err := json.MarshalIndent(t.state, "", "  ")
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	dir, err := filepath.Abs(filepath.Dir(t.path))
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	if err = os.MkdirAll(dir, 0755); errorutils.CheckError(err) != nil {
+		return err
+	}
+	tempFile, err := ioutil.TempFile(dir, filepath.Base(t.path)+".*.tmp")
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	_, err = tempFile.Write(data)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	return errorutils.CheckError(os.Rename(tempFile.Name(), t.path))
+}
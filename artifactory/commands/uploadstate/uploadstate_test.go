@@ -0,0 +1,198 @@
+package uploadstate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/utils/ioutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+)
+
+const serverUrl = "https://acme.jfrog.io/artifactory"
+
+// Creates a reader of the upload command result, which lists the uploaded files.
+func createResultReader(t *testing.T, uploaded map[string]string) *content.ContentReader {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	assert.NoError(t, err)
+	for localPath, targetPath := range uploaded {
+		writer.Write(clientutils.FileTransferDetails{SourcePath: localPath, TargetPath: serverUrl + "/" + targetPath})
+	}
+	assert.NoError(t, writer.Close())
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+}
+
+func getPatterns(filteredSpec *spec.SpecFiles) map[string]string {
+	patterns := make(map[string]string)
+	if filteredSpec != nil {
+		for _, file := range filteredSpec.Files {
+			patterns[file.Pattern] = file.Target
+		}
+	}
+	return patterns
+}
+
+func TestTracker(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "uploadstate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	aPath, bPath := filepath.Join(tempDir, "a.zip"), filepath.Join(tempDir, "sub", "b.zip")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(bPath), 0755))
+	assert.NoError(t, ioutil.WriteFile(aPath, []byte("a.zip"), 0644))
+	assert.NoError(t, ioutil.WriteFile(bPath, []byte("b.zip"), 0644))
+	statePath := filepath.Join(tempDir, "state", "upload-state.json")
+	uploadSpec := spec.NewBuilder().Pattern(filepath.Join(tempDir, "*.zip")).Target("repo/dir/").Props("a=b").Recursive(true).Flat(true).BuildSpec()
+
+	// The first upload uploads all the files.
+	tracker, err := NewTracker(statePath, serverUrl, nil)
+	assert.NoError(t, err)
+	filteredSpec, err := tracker.FilterSpec(uploadSpec, nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{aPath: "repo/dir/a.zip", bPath: "repo/dir/b.zip"}, getPatterns(filteredSpec))
+	assert.Equal(t, "a=b", filteredSpec.Get(0).Props)
+	// Only a.zip was uploaded successfully.
+	reader := createResultReader(t, map[string]string{aPath: "repo/dir/a.zip"})
+	defer reader.Close()
+	assert.NoError(t, tracker.Update(reader))
+	assert.NoError(t, tracker.Save())
+
+	// The next upload skips a.zip.
+	tracker, err = NewTracker(statePath, serverUrl+"/", nil)
+	assert.NoError(t, err)
+	filteredSpec, err = tracker.FilterSpec(uploadSpec, nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{bPath: "repo/dir/b.zip"}, getPatterns(filteredSpec))
+	assert.Equal(t, 1, tracker.Skipped())
+	fileState := tracker.state.Servers[serverUrl+"/"]["repo/dir/a.zip"]
+	if assert.NotNil(t, fileState) {
+		// The sha256 of 'a.zip', calculated locally since the upload result doesn't include it.
+		assert.Equal(t, "d42cf35afe0bcfc26772d92fe13bf3cdaf9b8cf8f0df8eebf569e6a88536e72f", fileState.Sha256)
+		assert.Equal(t, int64(5), fileState.Size)
+	}
+
+	// A modified file is uploaded again.
+	assert.NoError(t, os.Chtimes(aPath, time.Now(), time.Now().Add(time.Hour)))
+	tracker, err = NewTracker(statePath, serverUrl, nil)
+	assert.NoError(t, err)
+	filteredSpec, err = tracker.FilterSpec(uploadSpec, nil, 0)
+	assert.NoError(t, err)
+	assert.Len(t, getPatterns(filteredSpec), 2)
+
+	// The state is kept per server.
+	tracker, err = NewTracker(statePath, "https://other.jfrog.io/artifactory/", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, tracker.state.Servers["https://other.jfrog.io/artifactory/"])
+}
+
+func TestFilterSpecTooManyChangedFiles(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "uploadstate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	aPath, bPath, cPath := filepath.Join(tempDir, "a.zip"), filepath.Join(tempDir, "b.zip"), filepath.Join(tempDir, "c.zip")
+	for _, path := range []string{aPath, bPath, cPath} {
+		assert.NoError(t, ioutil.WriteFile(path, []byte(filepath.Base(path)), 0644))
+	}
+	statePath := filepath.Join(tempDir, "upload-state.json")
+	uploadSpec := spec.NewBuilder().Pattern(filepath.Join(tempDir, "*.zip")).Target("repo/").Flat(true).BuildSpec()
+	previousMax := maxFilteredFileSpecs
+	maxFilteredFileSpecs = 1
+	defer func() { maxFilteredFileSpecs = previousMax }()
+
+	// a.zip was uploaded before.
+	tracker, err := NewTracker(statePath, serverUrl, nil)
+	assert.NoError(t, err)
+	_, err = tracker.FilterSpec(spec.NewBuilder().Pattern(aPath).Target("repo/").Flat(true).BuildSpec(), nil, 0)
+	assert.NoError(t, err)
+	reader := createResultReader(t, map[string]string{aPath: "repo/a.zip"})
+	defer reader.Close()
+	assert.NoError(t, tracker.Update(reader))
+
+	// b.zip and c.zip were changed, which is more than the maximum, so the original spec is uploaded.
+	filteredSpec, err := tracker.FilterSpec(uploadSpec, nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uploadSpec, filteredSpec)
+	assert.Zero(t, tracker.Skipped())
+	// All the uploaded files are recorded.
+	reader = createResultReader(t, map[string]string{aPath: "repo/a.zip", bPath: "repo/b.zip", cPath: "repo/c.zip"})
+	defer reader.Close()
+	assert.NoError(t, tracker.Update(reader))
+	assert.Len(t, tracker.state.Servers[serverUrl+"/"], 3)
+}
+
+func TestFilterSpecUnsupportedOptions(t *testing.T) {
+	tracker, err := NewTracker(filepath.Join(os.TempDir(), "missing-upload-state.json"), serverUrl, nil)
+	assert.NoError(t, err)
+	for _, uploadSpec := range []*spec.SpecFiles{
+		spec.NewBuilder().Pattern("*.zip").Target("repo/").Explode("true").BuildSpec(),
+		spec.NewBuilder().Pattern("*.zip").Target("repo/").IncludeDirs(true).BuildSpec(),
+		spec.NewBuilder().Pattern("*.zip").Target("repo/").Archive("zip").BuildSpec(),
+	} {
+		_, err = tracker.FilterSpec(uploadSpec, nil, 0)
+		assert.Error(t, err)
+	}
+}
+
+func TestLoadUnsupportedVersion(t *testing.T) {
+	stateFile, err := ioutil.TempFile("", "uploadstate")
+	assert.NoError(t, err)
+	defer os.Remove(stateFile.Name())
+	_, err = stateFile.WriteString(`{"version":2,"servers":{}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, stateFile.Close())
+	_, err = NewTracker(stateFile.Name(), serverUrl, nil)
+	assert.Error(t, err)
+}
+
+func TestTrackerMultipleTargets(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "uploadstate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	aPath := filepath.Join(tempDir, "a.zip")
+	assert.NoError(t, ioutil.WriteFile(aPath, []byte("a.zip"), 0644))
+	statePath := filepath.Join(tempDir, "upload-state.json")
+	uploadSpec := spec.NewBuilder().Pattern(aPath).Target("repo/first/").Flat(true).BuildSpec()
+	uploadSpec.Files = append(uploadSpec.Files, spec.NewBuilder().Pattern(aPath).Target("repo/second/").Flat(true).BuildSpec().Files...)
+
+	tracker, err := NewTracker(statePath, serverUrl, nil)
+	assert.NoError(t, err)
+	filteredSpec, err := tracker.FilterSpec(uploadSpec, nil, 0)
+	assert.NoError(t, err)
+	assert.Len(t, filteredSpec.Files, 2)
+	// Only the upload to the second target succeeded.
+	reader := createResultReader(t, map[string]string{aPath: "repo/second/a.zip"})
+	defer reader.Close()
+	assert.NoError(t, tracker.Update(reader))
+	assert.Nil(t, tracker.state.Servers[serverUrl+"/"]["repo/first/a.zip"])
+	assert.NotNil(t, tracker.state.Servers[serverUrl+"/"]["repo/second/a.zip"])
+}
+
+func TestGetExactPattern(t *testing.T) {
+	escapedSep := regexp.QuoteMeta(string(filepath.Separator))
+	tests := []struct {
+		localPath, targetPath, expectedPattern string
+		isRegexp, recursive                    bool
+	}{
+		{filepath.Join("dir", "a.zip"), "repo/a.zip", filepath.Join("dir", "a.zip"), false, false},
+		{filepath.Join("dir", "a(1).zip"), "repo/a.zip", filepath.Join("dir", "a(1).zip"), false, false},
+		{filepath.Join("dir", "a(1).zip"), "repo/{1}/a.zip", "dir" + escapedSep + `(?:)a\(1\)\.zip$`, true, false},
+		{filepath.Join("dir", "b*", "a.zip"), "repo/a.zip", "dir" + escapedSep + `(?:)b\*` + escapedSep + `a\.zip$`, true, true},
+	}
+	for _, test := range tests {
+		t.Run(test.localPath, func(t *testing.T) {
+			if coreutils.IsWindows() && !test.isRegexp {
+				test.expectedPattern = ioutils.DoubleWinPathSeparator(test.expectedPattern)
+			}
+			pattern, isRegexp, recursive := getExactPattern(test.localPath, test.targetPath)
+			assert.Equal(t, test.expectedPattern, pattern)
+			assert.Equal(t, test.isRegexp, isRegexp)
+			assert.Equal(t, test.recursive, recursive)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/codegangsta/cli"
+	corecommon "github.com/jfrog/jfrog-cli-core/docs/common"
+	"github.com/jfrog/jfrog-cli/docs/audit/show"
+	auditutils "github.com/jfrog/jfrog-cli/utils/audit"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func GetCommands() []cli.Command {
+	return cliutils.GetSortedCommands(cli.CommandsByName{
+		{
+			Name:         "show",
+			Aliases:      []string{"s"},
+			Description:  show.Description,
+			Flags:        cliutils.GetCommandFlags(cliutils.AuditShow),
+			HelpName:     corecommon.CreateUsage("audit show", show.Description, show.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return showCmd(c)
+			},
+		},
+	})
+}
+
+var (
+	// The fields of the audit records, which can be selected using the --fields option.
+	auditRecordFields = []string{"timestamp", "user", "serverUser", "serverId", "command", "args", "affectedCount", "exitCode", "error"}
+	// The default columns of the audit records, in the table and csv formats.
+	auditRecordTableFields = []string{"timestamp", "user", "serverId", "command", "affectedCount", "exitCode"}
+)
+
+func showCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	filter, err := createFilter(c, time.Now())
+	if err != nil {
+		return err
+	}
+	outputOptions, err := cliutils.GetOutputOptions(c, cliutils.Table, auditRecordFields, auditRecordTableFields)
+	if err != nil {
+		return err
+	}
+	records, err := auditutils.ReadRecords(filter)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 && outputOptions.Format == cliutils.Table {
+		if !auditutils.IsEnabled() {
+			log.Info("No commands were recorded. To record the commands, set the " + auditutils.AuditLogEnv + " environment variable to true.")
+		} else {
+			log.Info("No commands were recorded.")
+		}
+		return nil
+	}
+	writer := cliutils.NewOutputWriter(outputOptions)
+	for _, record := range records {
+		if err = writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+func createFilter(c *cli.Context, now time.Time) (filter *auditutils.Filter, err error) {
+	filter = &auditutils.Filter{Command: c.String("command")}
+	if c.IsSet("from") {
+		if filter.From, err = auditutils.ParseTime(c.String("from"), now, false); err != nil {
+			return nil, err
+		}
+	}
+	if c.IsSet("to") {
+		if filter.To, err = auditutils.ParseTime(c.String("to"), now, true); err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
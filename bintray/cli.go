@@ -1,6 +1,7 @@
 package bintray
 
 import (
+	"encoding/json"
 	"errors"
 	corecommon "github.com/jfrog/jfrog-cli-core/docs/common"
 	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
@@ -35,6 +36,8 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/bintray/versionupdate"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	logUtils "github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/jfrog/jfrog-cli/utils/redact"
 	"github.com/jfrog/jfrog-client-go/bintray"
 	"github.com/jfrog/jfrog-client-go/bintray/auth"
 	"github.com/jfrog/jfrog-client-go/bintray/services"
@@ -103,7 +106,7 @@ func GetCommands() []cli.Command {
 		},
 		{
 			Name:         "package-show",
-			Flags:        getFlags(),
+			Flags:        append(getFlags(), cliutils.GetOutputFlags()...),
 			Aliases:      []string{"ps"},
 			Description:  packageshow.Description,
 			HelpName:     corecommon.CreateUsage("bt package-show", packageshow.Description, packageshow.Usage),
@@ -155,7 +158,7 @@ func GetCommands() []cli.Command {
 		},
 		{
 			Name:         "version-show",
-			Flags:        getFlags(),
+			Flags:        append(getFlags(), cliutils.GetOutputFlags()...),
 			Aliases:      []string{"vs"},
 			Description:  versionshow.Description,
 			HelpName:     corecommon.CreateUsage("bt version-show", versionshow.Description, versionshow.Usage),
@@ -705,8 +708,20 @@ func showPackage(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-
-	return commands.ShowPackage(btConfig, packagePath)
+	if !c.IsSet("format") && !c.IsSet("fields") {
+		return commands.ShowPackage(btConfig, packagePath)
+	}
+	outputOptions, err := cliutils.GetOutputOptions(c, cliutils.Json, nil, packageTableFields)
+	if err != nil {
+		return err
+	}
+	details, err := logUtils.CaptureOutput(func() error {
+		return commands.ShowPackage(btConfig, packagePath)
+	})
+	if err != nil {
+		return err
+	}
+	return cliutils.PrintOutputRecord(outputOptions, json.RawMessage(details))
 }
 
 func showVersion(c *cli.Context) error {
@@ -722,10 +737,30 @@ func showVersion(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-
-	return commands.ShowVersion(btConfig, versionPath)
+	if !c.IsSet("format") && !c.IsSet("fields") {
+		return commands.ShowVersion(btConfig, versionPath)
+	}
+	outputOptions, err := cliutils.GetOutputOptions(c, cliutils.Json, nil, versionTableFields)
+	if err != nil {
+		return err
+	}
+	details, err := logUtils.CaptureOutput(func() error {
+		return commands.ShowVersion(btConfig, versionPath)
+	})
+	if err != nil {
+		return err
+	}
+	return cliutils.PrintOutputRecord(outputOptions, json.RawMessage(details))
 }
 
+var (
+	// The default columns of the package and version details, in the table and csv formats.
+	// Any field returned by Bintray can be selected using the --fields option.
+	// The details are rendered from the output of the client's show services.
+	packageTableFields = []string{"name", "repo", "owner", "latest_version", "updated"}
+	versionTableFields = []string{"name", "package", "repo", "owner", "released", "updated"}
+)
+
 func createPackage(c *cli.Context) error {
 	if c.NArg() != 1 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
@@ -877,7 +912,7 @@ func downloadVersion(c *cli.Context) error {
 		return err
 	}
 	downloaded, failed, err := commands.DownloadVersion(btConfig, params)
-	err = cliutils.PrintSummaryReport(downloaded, failed, err)
+	err = cliutils.PrintSummaryReport(c, downloaded, failed, err)
 	if err != nil {
 		return err
 	}
@@ -931,7 +966,7 @@ func upload(c *cli.Context) error {
 		return err
 	}
 	uploaded, failed, err := commands.Upload(uploadConfig, params)
-	err = cliutils.PrintSummaryReport(uploaded, failed, err)
+	err = cliutils.PrintSummaryReport(c, uploaded, failed, err)
 	if err != nil {
 		return err
 	}
@@ -973,7 +1008,7 @@ func downloadFile(c *cli.Context) error {
 		return err
 	}
 	downloaded, failed, err := commands.DownloadFile(btConfig, params)
-	return cliutils.PrintSummaryReport(downloaded, failed, err)
+	return cliutils.PrintSummaryReport(c, downloaded, failed, err)
 }
 
 func signUrl(c *cli.Context) error {
@@ -1447,6 +1482,7 @@ func createBintrayDetails(c *cli.Context, includeConfig bool) (auth.BintrayDetai
 
 	btDetails.SetUser(user)
 	btDetails.SetKey(key)
+	redact.AddSecret(key)
 	btDetails.SetDefPackageLicense(defaultPackageLicenses)
 	return btDetails, nil
 }
@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"errors"
+	"time"
+
+	"github.com/codegangsta/cli"
+	corecommon "github.com/jfrog/jfrog-cli-core/docs/common"
+	"github.com/jfrog/jfrog-cli/docs/cache/prune"
+	"github.com/jfrog/jfrog-cli/docs/cache/stats"
+	cacheutils "github.com/jfrog/jfrog-cli/utils/cache"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func GetCommands() []cli.Command {
+	return cliutils.GetSortedCommands(cli.CommandsByName{
+		{
+			Name:         "prune",
+			Aliases:      []string{"p"},
+			Description:  prune.Description,
+			Flags:        cliutils.GetCommandFlags(cliutils.CachePrune),
+			HelpName:     corecommon.CreateUsage("cache prune", prune.Description, prune.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return pruneCmd(c)
+			},
+		},
+		{
+			Name:         "stats",
+			Aliases:      []string{"s"},
+			Description:  stats.Description,
+			Flags:        cliutils.GetCommandFlags(cliutils.CacheStats),
+			HelpName:     corecommon.CreateUsage("cache stats", stats.Description, stats.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return statsCmd(c)
+			},
+		},
+	})
+}
+
+var (
+	// The fields of the cache stats, which can be selected using the --fields option.
+	statsFields = []string{"dir", "files", "size", "maxSize", "oldestUse", "latestUse"}
+)
+
+func pruneCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	cache, err := openCache(c)
+	if err != nil {
+		return err
+	}
+	maxSize := cache.MaxSize()
+	if c.IsSet("max-size") {
+		if maxSize, err = cacheutils.ParseSize(c.String("max-size")); err != nil {
+			return err
+		}
+	}
+	var unusedSince time.Time
+	if c.IsSet("unused-for") {
+		unusedFor, err := time.ParseDuration(c.String("unused-for"))
+		if err != nil || unusedFor < 0 {
+			return errors.New("the --unused-for option should be a duration, such as 720h")
+		}
+		unusedSince = time.Now().Add(-unusedFor)
+	}
+	removedFiles, removedSize, err := cache.Prune(maxSize, unusedSince)
+	log.Info("Removed", removedFiles, "files of", cacheutils.FormatSize(removedSize), "from the download cache.")
+	return err
+}
+
+func statsCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	outputOptions, err := cliutils.GetOutputOptions(c, cliutils.Table, statsFields, statsFields)
+	if err != nil {
+		return err
+	}
+	cache, err := openCache(c)
+	if err != nil {
+		return err
+	}
+	cacheStats, err := cache.Stats()
+	if err != nil {
+		return err
+	}
+	return cliutils.PrintOutputRecord(outputOptions, cacheStats)
+}
+
+func openCache(c *cli.Context) (*cacheutils.Cache, error) {
+	dir := cacheutils.GetDir(c.String("cache-dir"))
+	if dir == "" {
+		return nil, errors.New("the download cache directory should be set using the --cache-dir option or the " + cacheutils.DownloadCacheEnv + " environment variable")
+	}
+	return cacheutils.New(dir)
+}
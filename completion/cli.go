@@ -4,8 +4,12 @@ import (
 	"github.com/codegangsta/cli"
 	corecommon "github.com/jfrog/jfrog-cli-core/docs/common"
 	"github.com/jfrog/jfrog-cli/completion/shells/bash"
+	"github.com/jfrog/jfrog-cli/completion/shells/fish"
+	"github.com/jfrog/jfrog-cli/completion/shells/powershell"
 	"github.com/jfrog/jfrog-cli/completion/shells/zsh"
 	bash_docs "github.com/jfrog/jfrog-cli/docs/completion/bash"
+	fish_docs "github.com/jfrog/jfrog-cli/docs/completion/fish"
+	powershell_docs "github.com/jfrog/jfrog-cli/docs/completion/powershell"
 	zsh_docs "github.com/jfrog/jfrog-cli/docs/completion/zsh"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 )
@@ -30,5 +34,23 @@ func GetCommands() []cli.Command {
 				zsh.WriteZshCompletionScript()
 			},
 		},
+		{
+			Name:         "fish",
+			Description:  fish_docs.Description,
+			HelpName:     corecommon.CreateUsage("completion fish", fish_docs.Description, fish_docs.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(*cli.Context) {
+				fish.WriteFishCompletionScript()
+			},
+		},
+		{
+			Name:         "powershell",
+			Description:  powershell_docs.Description,
+			HelpName:     corecommon.CreateUsage("completion powershell", powershell_docs.Description, powershell_docs.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(*cli.Context) {
+				powershell.WritePowershellCompletionScript()
+			},
+		},
 	})
 }
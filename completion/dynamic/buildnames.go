@@ -0,0 +1,58 @@
+package dynamic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	buildNamesFileName = "build-names.json"
+	// The number of recently published build names kept for completion.
+	maxRecentBuildNames = 20
+)
+
+// Returns the names of the recently published builds, starting with the most recent one.
+func getRecentBuildNames() []string {
+	cacheDir, err := getCompletionCacheDir()
+	if err != nil {
+		return nil
+	}
+	content, err := ioutil.ReadFile(filepath.Join(cacheDir, buildNamesFileName))
+	if err != nil {
+		return nil
+	}
+	var buildNames []string
+	if err = json.Unmarshal(content, &buildNames); err != nil {
+		return nil
+	}
+	return buildNames
+}
+
+// Stores the name of a published build, to be suggested when completing the --build-name option.
+func RecordBuildName(buildName string) error {
+	if buildName == "" {
+		return nil
+	}
+	cacheDir, err := getCompletionCacheDir()
+	if err != nil {
+		return err
+	}
+	buildNames := []string{buildName}
+	for _, recent := range getRecentBuildNames() {
+		if recent != buildName && len(buildNames) < maxRecentBuildNames {
+			buildNames = append(buildNames, recent)
+		}
+	}
+	if err = os.MkdirAll(cacheDir, 0700); err != nil {
+		return errorutils.CheckError(err)
+	}
+	content, err := json.Marshal(buildNames)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(filepath.Join(cacheDir, buildNamesFileName), content, 0600))
+}
@@ -0,0 +1,58 @@
+package dynamic
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/codegangsta/cli"
+	coreCommonCommands "github.com/jfrog/jfrog-cli-core/common/commands"
+	corecommon "github.com/jfrog/jfrog-cli-core/docs/common"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+)
+
+// The dynamic completion caches are stored in this dir, under the JFrog home dir.
+const completionCacheDirName = "completion"
+
+// Creates a completion function for commands which their arguments are Artifactory paths.
+// After the --server-id option, the configured server IDs are suggested.
+// After the --build-name option, the names of recently published builds are suggested.
+// Otherwise, the command's flags are suggested, along with the repositories of the selected Artifactory server.
+func CreateArtifactoryPathsCompletionFunc() cli.BashCompleteFunc {
+	return func(c *cli.Context) {
+		switch getPreviousWord() {
+		case "--server-id":
+			printSuggestions(coreCommonCommands.GetAllServerIds())
+		case "--build-name":
+			printSuggestions(getRecentBuildNames())
+		default:
+			corecommon.CreateBashCompletionFunc()(c)
+			for _, repoKey := range getRepositoriesKeys(c.String("server-id")) {
+				fmt.Println(repoKey + "/")
+			}
+		}
+	}
+}
+
+// Returns the last word typed before the word being completed.
+// The completion scripts pass the typed words to the CLI, followed by the completion flag.
+func getPreviousWord() string {
+	if len(os.Args) < 3 {
+		return ""
+	}
+	return os.Args[len(os.Args)-2]
+}
+
+func printSuggestions(suggestions []string) {
+	for _, suggestion := range suggestions {
+		fmt.Println(suggestion)
+	}
+}
+
+func getCompletionCacheDir() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, completionCacheDirName), nil
+}
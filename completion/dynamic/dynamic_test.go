@@ -0,0 +1,74 @@
+package dynamic
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPreviousWord(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"jfrog", "rt", "u", "--server-id", "--generate-bash-completion"}
+	assert.Equal(t, "--server-id", getPreviousWord())
+	os.Args = []string{"jfrog", "--generate-bash-completion"}
+	assert.Equal(t, "", getPreviousWord())
+}
+
+func TestCachedRepositoriesExpiration(t *testing.T) {
+	now := time.Now()
+	assert.False(t, (&cachedRepositories{Timestamp: now.Unix()}).isExpired(now))
+	assert.True(t, (&cachedRepositories{Timestamp: now.Add(-repositoriesCacheTtl - time.Minute).Unix()}).isExpired(now))
+}
+
+func TestRepositoriesCache(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	cacheDir := filepath.Join(tmpDir, completionCacheDirName)
+	cachePath := filepath.Join(cacheDir, repositoriesCacheFileName)
+
+	// A missing cache should be read as an empty cache.
+	assert.Empty(t, readRepositoriesCache(cachePath))
+
+	cache := repositoriesCache{"server": {Timestamp: 1, Keys: []string{"generic-local", "npm-remote"}}}
+	if !assert.NoError(t, writeRepositoriesCache(cacheDir, cachePath, cache)) {
+		return
+	}
+	assert.Equal(t, cache, readRepositoriesCache(cachePath))
+}
+
+func TestRecordBuildName(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	oldHomeDir := os.Getenv(coreutils.HomeDir)
+	defer os.Setenv(coreutils.HomeDir, oldHomeDir)
+	assert.NoError(t, os.Setenv(coreutils.HomeDir, tmpDir))
+
+	assert.Empty(t, getRecentBuildNames())
+	for _, buildName := range []string{"first", "second", "first", ""} {
+		assert.NoError(t, RecordBuildName(buildName))
+	}
+	// The most recent build name should be first, without duplicates.
+	assert.Equal(t, []string{"first", "second"}, getRecentBuildNames())
+
+	// Only the most recent build names should be kept.
+	for i := 0; i < maxRecentBuildNames+5; i++ {
+		assert.NoError(t, RecordBuildName("build-"+strconv.Itoa(i)))
+	}
+	buildNames := getRecentBuildNames()
+	assert.Len(t, buildNames, maxRecentBuildNames)
+	assert.Equal(t, "build-"+strconv.Itoa(maxRecentBuildNames+4), buildNames[0])
+}
@@ -0,0 +1,131 @@
+package dynamic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientConfig "github.com/jfrog/jfrog-client-go/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	repositoriesCacheFileName = "repositories.json"
+	// The repositories are fetched from Artifactory again, once their cache expires.
+	repositoriesCacheTtl = 5 * time.Minute
+	// Completion should not block the shell for long, if Artifactory is unreachable.
+	repositoriesRequestTimeout = 3 * time.Second
+)
+
+// The repositories keys of the configured Artifactory servers, mapped by server ID.
+type repositoriesCache map[string]*cachedRepositories
+
+type cachedRepositories struct {
+	Timestamp int64    `json:"timestamp"`
+	Keys      []string `json:"keys"`
+}
+
+func (cached *cachedRepositories) isExpired(now time.Time) bool {
+	return now.Sub(time.Unix(cached.Timestamp, 0)) > repositoriesCacheTtl
+}
+
+// Returns the repositories keys of the provided configured server, or of the default server if no server ID is provided.
+// The keys are fetched from Artifactory only if the cached keys expired.
+// Errors are only logged, to keep the completion output intact.
+func getRepositoriesKeys(serverId string) []string {
+	serverDetails, err := config.GetSpecificConfig(serverId, true, true)
+	if err != nil || serverDetails.ArtifactoryUrl == "" {
+		return nil
+	}
+	cacheDir, err := getCompletionCacheDir()
+	if err != nil {
+		return nil
+	}
+	cachePath := filepath.Join(cacheDir, repositoriesCacheFileName)
+	cache := readRepositoriesCache(cachePath)
+	now := time.Now()
+	if cached, ok := cache[serverDetails.ServerId]; ok && !cached.isExpired(now) {
+		return cached.Keys
+	}
+	keys, err := fetchRepositoriesKeys(serverDetails)
+	if err != nil {
+		log.Debug("Failed fetching the repositories for completion: " + err.Error())
+		return nil
+	}
+	cache[serverDetails.ServerId] = &cachedRepositories{Timestamp: now.Unix(), Keys: keys}
+	if err = writeRepositoriesCache(cacheDir, cachePath, cache); err != nil {
+		log.Debug("Failed writing the repositories completion cache: " + err.Error())
+	}
+	return keys
+}
+
+// A missing or corrupted cache results in an empty cache.
+func readRepositoriesCache(cachePath string) repositoriesCache {
+	cache := repositoriesCache{}
+	content, err := ioutil.ReadFile(cachePath)
+	if err != nil {
+		return cache
+	}
+	if err = json.Unmarshal(content, &cache); err != nil {
+		return repositoriesCache{}
+	}
+	return cache
+}
+
+func writeRepositoriesCache(cacheDir, cachePath string, cache repositoriesCache) error {
+	if err := os.MkdirAll(cacheDir, 0700); err != nil {
+		return errorutils.CheckError(err)
+	}
+	content, err := json.Marshal(cache)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(cachePath, content, 0600))
+}
+
+func fetchRepositoriesKeys(serverDetails *config.ServerDetails) ([]string, error) {
+	certsPath, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		return nil, err
+	}
+	artAuth, err := serverDetails.CreateArtAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	serviceConfig, err := clientConfig.NewConfigBuilder().
+		SetServiceDetails(artAuth).
+		SetCertificatesPath(certsPath).
+		SetInsecureTls(serverDetails.InsecureTls).
+		SetHttpTimeout(repositoriesRequestTimeout).
+		SetHttpRetries(0).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	servicesManager, err := artifactory.New(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+	// The client's logs would clutter the shell while completing, unless debugging.
+	if log.GetLogLevel() != log.DEBUG {
+		defer log.SetLogger(log.Logger)
+		log.SetLogger(log.NewLogger(log.ERROR, nil))
+	}
+	repositories, err := servicesManager.GetAllRepositories()
+	if err != nil {
+		return nil, err
+	}
+	keys := []string{}
+	for _, repo := range *repositories {
+		keys = append(keys, repo.Key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
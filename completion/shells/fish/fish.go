@@ -0,0 +1,48 @@
+package fish
+
+//go:generate go run ../generate_scripts.go
+
+import (
+	"fmt"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+	"path/filepath"
+)
+
+const FishAutocomplete = `function __jfrog_complete
+	set -l tokens (commandline -opc)
+	set -l current (commandline -ct)
+	set -l opts (command $tokens --generate-bash-completion 2>/dev/null)
+	if test (count $opts) -eq 0; and not string match -q -- '-*' $current
+		__fish_complete_path $current
+		return
+	end
+	printf '%s\n' $opts
+end
+
+complete -c jfrog -f -a '(__jfrog_complete)'
+`
+
+func WriteFishCompletionScript() {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	completionPath := filepath.Join(homeDir, "jfrog_fish_completion")
+	if err = ioutil.WriteFile(completionPath, []byte(FishAutocomplete), 0600); err != nil {
+		log.Error(err)
+		return
+	}
+	sourceCommand := "source " + completionPath + ""
+	fmt.Printf(`Generated fish completion script at %s.
+To activate auto-completion on this shell only, source the completion script by running the following command:
+
+%s
+
+To activate auto-completion permanently, copy the completion script to ~/.config/fish/completions/jfrog.fish.
+
+`,
+		completionPath, sourceCommand)
+}
@@ -1,6 +1,6 @@
 // +build ignore
 
-// This program generates bash and zsh completion scripts.
+// This program generates bash, zsh, fish and PowerShell completion scripts.
 // It can be invoked by running 'go generate ./completion/shells/...'
 package main
 
@@ -12,6 +12,8 @@ import (
 
 	"github.com/jfrog/jfrog-cli-core/utils/log"
 	"github.com/jfrog/jfrog-cli/completion/shells/bash"
+	"github.com/jfrog/jfrog-cli/completion/shells/fish"
+	"github.com/jfrog/jfrog-cli/completion/shells/powershell"
 	"github.com/jfrog/jfrog-cli/completion/shells/zsh"
 )
 
@@ -20,19 +22,23 @@ func main() {
 	dir, err := os.Getwd()
 	coreutils.ExitOnErr(err)
 	if strings.HasSuffix(dir, "bash") {
-		writeScript(bash.BashAutocomplete)
+		writeScript("jfrog", bash.BashAutocomplete)
 	} else if strings.HasSuffix(dir, "zsh") {
-		writeScript(zsh.ZshAutocomplete)
+		writeScript("jfrog", zsh.ZshAutocomplete)
+	} else if strings.HasSuffix(dir, "fish") {
+		writeScript("jfrog.fish", fish.FishAutocomplete)
+	} else if strings.HasSuffix(dir, "powershell") {
+		writeScript("jfrog.ps1", powershell.PowershellAutocomplete)
 	} else {
 		coreutils.ExitOnErr(errors.New("Unexpected script to create"))
 	}
 }
 
-func writeScript(script string) {
-	scriptFile, err := os.Create("jfrog")
+func writeScript(fileName, script string) {
+	scriptFile, err := os.Create(fileName)
 	coreutils.ExitOnErr(err)
 	defer scriptFile.Close()
-	err = os.Chmod("jfrog", os.ModePerm)
+	err = os.Chmod(fileName, os.ModePerm)
 	coreutils.ExitOnErr(err)
 	_, err = scriptFile.WriteString(script)
 	coreutils.ExitOnErr(err)
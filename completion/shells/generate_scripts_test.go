@@ -2,6 +2,8 @@ package main
 
 import (
 	"github.com/jfrog/jfrog-cli/completion/shells/bash"
+	"github.com/jfrog/jfrog-cli/completion/shells/fish"
+	"github.com/jfrog/jfrog-cli/completion/shells/powershell"
 	"github.com/jfrog/jfrog-cli/completion/shells/zsh"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
@@ -14,12 +16,18 @@ import (
 func TestGenerateScripts(t *testing.T) {
 	bashPath := filepath.Join("bash", "jfrog")
 	zshPath := filepath.Join("zsh", "jfrog")
+	fishPath := filepath.Join("fish", "jfrog.fish")
+	powershellPath := filepath.Join("powershell", "jfrog.ps1")
 
 	// Make sure test environment is clean before and after test
 	os.Remove(bashPath)
 	os.Remove(zshPath)
+	os.Remove(fishPath)
+	os.Remove(powershellPath)
 	defer os.Remove(bashPath)
 	defer os.Remove(zshPath)
+	defer os.Remove(fishPath)
+	defer os.Remove(powershellPath)
 
 	// Run go generate ./...
 	cmd := exec.Command("go", "generate", "./...")
@@ -39,4 +47,18 @@ func TestGenerateScripts(t *testing.T) {
 	assert.NoError(t, err)
 	b, err = ioutil.ReadAll(zshFile)
 	assert.Equal(t, zsh.ZshAutocomplete, string(b))
+
+	// Check fish completion script
+	fishFile, err := os.Open(fishPath)
+	defer fishFile.Close()
+	assert.NoError(t, err)
+	b, err = ioutil.ReadAll(fishFile)
+	assert.Equal(t, fish.FishAutocomplete, string(b))
+
+	// Check PowerShell completion script
+	powershellFile, err := os.Open(powershellPath)
+	defer powershellFile.Close()
+	assert.NoError(t, err)
+	b, err = ioutil.ReadAll(powershellFile)
+	assert.Equal(t, powershell.PowershellAutocomplete, string(b))
 }
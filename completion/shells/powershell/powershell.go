@@ -0,0 +1,51 @@
+package powershell
+
+//go:generate go run ../generate_scripts.go
+
+import (
+	"fmt"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+	"path/filepath"
+)
+
+const PowershellAutocomplete = `Register-ArgumentCompleter -Native -CommandName jfrog -ScriptBlock {
+	param($wordToComplete, $commandAst, $cursorPosition)
+	$words = @($commandAst.CommandElements | Where-Object { $_.Extent.StartOffset -lt $cursorPosition } | ForEach-Object { $_.ToString() })
+	# The word being completed is not passed to jfrog.
+	if ($wordToComplete -ne '' -and $words.Count -gt 1) {
+		$words = $words[0..($words.Count - 2)]
+	}
+	$arguments = @()
+	if ($words.Count -gt 1) {
+		$arguments = $words[1..($words.Count - 1)]
+	}
+	& $words[0] @arguments --generate-bash-completion 2>$null | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+		[System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+	}
+}
+`
+
+func WritePowershellCompletionScript() {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	completionPath := filepath.Join(homeDir, "jfrog_powershell_completion.ps1")
+	if err = ioutil.WriteFile(completionPath, []byte(PowershellAutocomplete), 0600); err != nil {
+		log.Error(err)
+		return
+	}
+	sourceCommand := ". " + completionPath + ""
+	fmt.Printf(`Generated PowerShell completion script at %s.
+To activate auto-completion on this shell only, source the completion script by running the following command:
+
+%s
+
+To activate auto-completion permanently, put the above command in your PowerShell profile. Its path is stored in the $PROFILE variable.
+
+`,
+		completionPath, sourceCommand)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/codegangsta/cli"
 	"github.com/jfrog/jfrog-cli-core/common/commands"
 	corecommon "github.com/jfrog/jfrog-cli-core/docs/common"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
 	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
 	"github.com/jfrog/jfrog-cli/docs/config/add"
 	"github.com/jfrog/jfrog-cli/docs/config/edit"
@@ -47,6 +48,7 @@ func GetCommands() []cli.Command {
 			Name:         "show",
 			Aliases:      []string{"s"},
 			Description:  show.Description,
+			Flags:        cliutils.GetCommandFlags(cliutils.ShowConfig),
 			HelpName:     corecommon.CreateUsage("c show", show.Description, show.Usage),
 			BashComplete: corecommon.CreateBashCompletionFunc(commands.GetAllServerIds()...),
 			Action: func(c *cli.Context) error {
@@ -159,7 +161,91 @@ func showCmd(c *cli.Context) error {
 	if c.NArg() == 1 {
 		serverId = c.Args()[0]
 	}
-	return commands.ShowConfig(serverId)
+	if !c.IsSet("format") && !c.IsSet("fields") {
+		return commands.ShowConfig(serverId)
+	}
+	outputOptions, err := cliutils.GetOutputOptions(c, cliutils.Table, serverConfigFields, serverConfigTableFields)
+	if err != nil {
+		return err
+	}
+	return printServersConfigs(serverId, outputOptions)
+}
+
+var (
+	// The fields of the servers configurations, which can be selected using the --fields option.
+	serverConfigFields = []string{"serverId", "url", "artifactoryUrl", "distributionUrl", "xrayUrl", "missionControlUrl", "pipelinesUrl",
+		"user", "password", "apiKey", "accessToken", "refreshToken", "sshKeyPath", "clientCertPath", "clientCertKeyPath", "isDefault"}
+	// The default columns of the servers configurations, in the table and csv formats.
+	serverConfigTableFields = []string{"serverId", "url", "artifactoryUrl", "user", "isDefault"}
+)
+
+// A server configuration, as rendered using the --format option. Secrets are masked.
+type serverConfigRecord struct {
+	ServerId          string `json:"serverId"`
+	Url               string `json:"url"`
+	ArtifactoryUrl    string `json:"artifactoryUrl"`
+	DistributionUrl   string `json:"distributionUrl"`
+	XrayUrl           string `json:"xrayUrl"`
+	MissionControlUrl string `json:"missionControlUrl"`
+	PipelinesUrl      string `json:"pipelinesUrl"`
+	User              string `json:"user"`
+	Password          string `json:"password"`
+	ApiKey            string `json:"apiKey"`
+	AccessToken       string `json:"accessToken"`
+	RefreshToken      string `json:"refreshToken"`
+	SshKeyPath        string `json:"sshKeyPath"`
+	ClientCertPath    string `json:"clientCertPath"`
+	ClientCertKeyPath string `json:"clientCertKeyPath"`
+	IsDefault         bool   `json:"isDefault"`
+}
+
+func printServersConfigs(serverId string, outputOptions *cliutils.OutputOptions) error {
+	var configurations []*config.ServerDetails
+	if serverId != "" {
+		serverDetails, err := config.GetSpecificConfig(serverId, true, false)
+		if err != nil {
+			return err
+		}
+		configurations = []*config.ServerDetails{serverDetails}
+	} else {
+		var err error
+		configurations, err = config.GetAllServersConfigs()
+		if err != nil {
+			return err
+		}
+	}
+	writer := cliutils.NewOutputWriter(outputOptions)
+	for _, details := range configurations {
+		record := &serverConfigRecord{
+			ServerId:          details.ServerId,
+			Url:               details.Url,
+			ArtifactoryUrl:    details.ArtifactoryUrl,
+			DistributionUrl:   details.DistributionUrl,
+			XrayUrl:           details.XrayUrl,
+			MissionControlUrl: details.MissionControlUrl,
+			PipelinesUrl:      details.PipelinesUrl,
+			User:              details.User,
+			Password:          maskSecret(details.Password),
+			ApiKey:            maskSecret(details.ApiKey),
+			AccessToken:       maskSecret(details.AccessToken),
+			RefreshToken:      maskSecret(details.RefreshToken),
+			SshKeyPath:        details.SshKeyPath,
+			ClientCertPath:    details.ClientCertPath,
+			ClientCertKeyPath: details.ClientCertKeyPath,
+			IsDefault:         details.IsDefault,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "***"
 }
 
 func deleteCmd(c *cli.Context) error {
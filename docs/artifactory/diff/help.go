@@ -0,0 +1,18 @@
+package diff
+
+const Description = "Compare a local directory with a repository path, or two repository paths."
+
+var Usage = []string{"jfrog rt diff [command options] <source pattern> <target path>",
+	"jfrog rt diff --spec=<File Spec path> [command options]"}
+
+const Arguments string = `	source pattern
+		Specifies the local file system path to the compared files, resolved the same way the upload command resolves its source pattern.
+		If the --remote-source option is set, specifies the source path in Artifactory in the following format: <repository name>/<repository path>,
+		resolved the same way the search command resolves its search pattern.
+
+	target path
+		Specifies the target path in Artifactory in the following format: <repository name>/<repository path>.
+		The local files are compared with the paths the upload command would upload them to, including the placeholders of the target path.
+		All the files under the target path are compared, so the files which exist only in the target are the files "rt upload --sync-deletes" would delete.
+
+	The command reports the files which exist only in the source, only in the target, or in both with a different sha256 checksum.`
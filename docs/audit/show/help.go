@@ -0,0 +1,5 @@
+package show
+
+const Description = "Show the commands recorded in the local audit log, which is enabled by setting the JFROG_CLI_AUDIT_LOG environment variable to true."
+
+var Usage = []string{"jfrog audit show [command options]"}
@@ -0,0 +1,5 @@
+package prune
+
+const Description = "Remove files from the local download cache. By default, the least recently used files are removed until the cache doesn't exceed its maximal size."
+
+var Usage = []string{"jfrog cache prune [command options]"}
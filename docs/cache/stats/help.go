@@ -0,0 +1,5 @@
+package stats
+
+const Description = "Show the number of files in the local download cache, their total size, and when they were used."
+
+var Usage = []string{"jfrog cache stats [command options]"}
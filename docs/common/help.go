@@ -7,6 +7,11 @@ const GlobalEnvVars string = `	JFROG_CLI_LOG_LEVEL
 		If set to ERROR, JFrog CLI logs error messages only.
 		It is useful when you wish to read or parse the JFrog CLI output and do not want any other information logged.
 
+	JFROG_CLI_LOG_FORMAT
+		[Default: text]
+		Set to json to log one JSON object per line, with the level, timestamp, command and message fields.
+		The command output is not affected.
+
 	JFROG_CLI_OFFER_CONFIG
 		[Default: true]
 		If true, JFrog CLI prompts for product server details and saves them in its config file.
@@ -37,6 +42,44 @@ const GlobalEnvVars string = `	JFROG_CLI_LOG_LEVEL
 		[Default: *password*;*psw*;*secret*;*key*;*token*] 
 		List of case insensitive patterns in the form of "value1;value2;...". Environment variables match those patterns will be excluded. This environment variable is used by the "jfrog rt build-publish" command, in case the --env-exclude command option is not sent.
 
+	JFROG_CLI_REDACT_PATTERNS
+		[Default: *password*;*psw*;*secret*;*apikey*;*api_key*;*private_key*;*token*;*passphrase*;*credential*]
+		List of case insensitive patterns in the form of "value1;value2;...". The values of the environment variables matching those patterns are masked in the logs, the error messages and the summaries, and in the environment variables collected by the "jfrog rt build-collect-env" command.
+		Access tokens, API keys, URL passwords, AWS keys and private keys are masked as well, and so are the credentials passed as command options or read from the config.
+
+	JFROG_CLI_AUDIT_LOG
+		[Default: false]
+		Set to true to record the commands which change artifacts, builds, repositories, permissions, users and groups in a local audit log, under the audit directory of the JFrog home dir.
+		Each record includes the time, the user, the server ID, the command with its options (credentials masked), the number of affected artifacts and the exit code.
+		Use the "jfrog audit show" command to display the audit log.
+
+	JFROG_CLI_DOWNLOAD_CACHE
+		Path to a local download cache, used by the "jfrog rt download" command unless its --cache-dir option is set.
+		The downloaded files are stored in the cache by their sha256 checksums, and are copied from it when they're downloaded again.
+		Use the "jfrog cache stats" and "jfrog cache prune" commands to manage the cache.
+
+	JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE
+		[Default: 10GB]
+		The maximal size of the download cache, such as 500MB or 20GB. The least recently used files are removed when the cache exceeds it.
+
+	JFROG_CLI_PROGRESS_EVENTS
+		Reports the progress of file transfers as non-interactive events, instead of the progress bar.
+		Useful in CI, where the progress bar isn't displayed.
+		Possible values are: log (a periodic progress line) and json (newline-delimited JSON events, for each file started and finished, and periodically for the total progress).
+
+	JFROG_CLI_PROGRESS_EVENTS_FILE
+		[Default: stderr]
+		A file to append the progress events to.
+
+	JFROG_CLI_PROGRESS_EVENTS_INTERVAL
+		[Default: 10]
+		The interval in seconds between the periodic progress events.
+
+	OTEL_EXPORTER_OTLP_ENDPOINT
+		The base URL of an OpenTelemetry collector. If set, the traces of the command are exported to its /v1/traces path, using OTLP/HTTP with the JSON encoding.
+		The standard OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, OTEL_EXPORTER_OTLP_HEADERS and OTEL_SERVICE_NAME variables are supported as well.
+		If TRACEPARENT is set, the command is traced as a child of the W3C trace context it holds.
+
 	CI
 		[Default: false]
 		If true, disables interactive prompts and progress bar.
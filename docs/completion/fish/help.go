@@ -0,0 +1,5 @@
+package fish
+
+const Description = "Generate fish completion script."
+
+var Usage = []string{"jfrog completion fish"}
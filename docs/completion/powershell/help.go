@@ -0,0 +1,5 @@
+package powershell
+
+const Description = "Generate PowerShell completion script."
+
+var Usage = []string{"jfrog completion powershell"}
@@ -2,12 +2,14 @@ package install
 
 const Description = "Install a JFrog CLI plugin."
 
-var Usage = []string{"jfrog plugin install <plugin name and version>"}
+var Usage = []string{"jfrog plugin install [command options] <plugin name and version>"}
 
 const Arguments string = `	plugin name and version
 		Specifies the name and version of the JFrog CLI Plugin you wish to install from the plugins registry.
 		The version should be specified after a '@' separator, such as: 'hello-frog@1.0.0'. 
-		To download the latest version, specify the plugin name only.`
+		To download the latest version, specify the plugin name only.
+		Before being installed, the plugin is verified using its published sha256 checksum manifest and GPG signature.
+		The signature is verified against the public keys stored in the trusted keyring, at ~/.jfrog/security/plugins-trusted-keys.asc`
 
 const EnvVar string = `	JFROG_CLI_PLUGINS_SERVER
 		[Default: Official JFrog CLI Plugins registry]
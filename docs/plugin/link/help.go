@@ -0,0 +1,9 @@
+package link
+
+const Description = "Link a local JFrog CLI plugin, for developing it."
+
+var Usage = []string{"jfrog plugin link <path to plugin module>"}
+
+const Arguments string = `	path to plugin module
+		Specifies the path to the root dir of the plugin's Go module.
+		The plugin is named after the last element of its module path, and is rebuilt automatically using 'go build' when it is run or listed after its sources are modified.`
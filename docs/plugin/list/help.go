@@ -0,0 +1,14 @@
+package list
+
+const Description = "List the installed JFrog CLI plugins, including the linked development plugins."
+
+var Usage = []string{"jfrog plugin list [command options]"}
+
+const EnvVar string = `	JFROG_CLI_PLUGINS_SERVER
+		[Default: Official JFrog CLI Plugins registry]
+		Configured Artifactory server ID from which to get the latest versions of the JFrog CLI Plugins.
+
+	JFROG_CLI_PLUGINS_REPO
+		[Default: 'jfrog-cli-plugins']
+		Can be optionally used with the JFROG_CLI_PLUGINS_SERVER environment variable.
+		Determines the name of the local repository to use.`
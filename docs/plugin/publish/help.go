@@ -2,13 +2,16 @@ package publish
 
 const Description = "Publishing a JFrog CLI plugin."
 
-var Usage = []string{"jfrog plugin publish <plugin name> <plugin version>"}
+var Usage = []string{"jfrog plugin publish [command options] <plugin name> <plugin version>"}
 
 const Arguments string = `	plugin name
 		Specifies the name of the JFrog CLI Plugin you wish to publish. You should run this command from the plugin's directory.
 
 	plugin version
-		Specifies the version of the JFrog CLI Plugin you wish to publish.`
+		Specifies the version of the JFrog CLI Plugin you wish to publish.
+		The plugin is built for all the requested architectures in parallel, and is uploaded only if all builds succeed.
+		If an upload fails, the uploaded version is deleted and the latest version is not updated. If updating the latest version fails, the previous latest version is kept.
+		The latest version is updated only when the plugin is published for all the supported architectures.`
 
 const EnvVar string = `	JFROG_CLI_PLUGINS_SERVER
 		[Mandatory]
@@ -18,4 +21,8 @@ const EnvVar string = `	JFROG_CLI_PLUGINS_SERVER
 	JFROG_CLI_PLUGINS_REPO
 		[Default: 'jfrog-cli-plugins']
 		Can be optionally used with the JFROG_CLI_PLUGINS_SERVER environment variable.
-		Determines the name of the local repository to use.`
+		Determines the name of the local repository to use.
+
+	JFROG_CLI_PLUGINS_SIGNING_KEY_PASSPHRASE
+		Can be optionally used with the --signing-key option.
+		The passphrase of the provided GPG private key, if the key is encrypted.`
@@ -0,0 +1,12 @@
+package run
+
+const Description = "Run an installed JFrog CLI plugin."
+
+var Usage = []string{"jfrog plugin run <plugin name> [plugin arguments and options]"}
+
+const Arguments string = `	plugin name
+		Specifies the name of the installed JFrog CLI Plugin you wish to run. The plugin's command name can also be used.
+		Use this command to run plugins which are not registered as commands, since their names collide with built-in commands or with other plugins.
+
+	plugin arguments and options
+		The arguments and options passed to the plugin.`
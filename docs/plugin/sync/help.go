@@ -0,0 +1,14 @@
+package sync
+
+const Description = "Install the JFrog CLI plugins pinned in the project's plugins lock file, located at .jfrog/plugins.yaml in the current directory or one of its parents."
+
+var Usage = []string{"jfrog plugin sync [command options]"}
+
+const EnvVar string = `	JFROG_CLI_PLUGINS_SERVER
+		[Default: Official JFrog CLI Plugins registry]
+		Configured Artifactory server ID from which to download JFrog CLI Plugins.
+
+	JFROG_CLI_PLUGINS_REPO
+		[Default: 'jfrog-cli-plugins']
+		Can be optionally used with the JFROG_CLI_PLUGINS_SERVER environment variable.
+		Determines the name of the local repository to use.`
@@ -0,0 +1,8 @@
+package unlink
+
+const Description = "Unlink a local JFrog CLI plugin, linked for development."
+
+var Usage = []string{"jfrog plugin unlink <plugin name>"}
+
+const Arguments string = `	plugin name
+		Specifies the name of the linked JFrog CLI Plugin you wish to unlink.`
@@ -0,0 +1,19 @@
+package upgrade
+
+const Description = "Upgrade installed JFrog CLI plugins to their latest version."
+
+var Usage = []string{"jfrog plugin upgrade [command options] <plugin name>",
+	"jfrog plugin upgrade --all [command options]"}
+
+const Arguments string = `	plugin name
+		Specifies the name of the installed JFrog CLI Plugin you wish to upgrade.
+		The plugin is downloaded only if its latest version in the plugins registry differs from the local one.`
+
+const EnvVar string = `	JFROG_CLI_PLUGINS_SERVER
+		[Default: Official JFrog CLI Plugins registry]
+		Configured Artifactory server ID from which to download JFrog CLI Plugins.
+
+	JFROG_CLI_PLUGINS_REPO
+		[Default: 'jfrog-cli-plugins']
+		Can be optionally used with the JFROG_CLI_PLUGINS_SERVER environment variable.
+		Determines the name of the local repository to use.`
@@ -2,10 +2,10 @@ package main
 
 import (
 	"os"
+	"strings"
 
 	corecommon "github.com/jfrog/jfrog-cli-core/docs/common"
 	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
-	"github.com/jfrog/jfrog-cli-core/utils/log"
 	"github.com/jfrog/jfrog-cli/config"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli/docs/general/cisetup"
@@ -15,10 +15,16 @@ import (
 
 	"github.com/codegangsta/cli"
 	"github.com/jfrog/jfrog-cli/artifactory"
+	"github.com/jfrog/jfrog-cli/audit"
 	"github.com/jfrog/jfrog-cli/bintray"
+	"github.com/jfrog/jfrog-cli/cache"
 	"github.com/jfrog/jfrog-cli/completion"
 	"github.com/jfrog/jfrog-cli/missioncontrol"
+	auditutils "github.com/jfrog/jfrog-cli/utils/audit"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/jfrog/jfrog-cli/utils/redact"
+	"github.com/jfrog/jfrog-cli/utils/tracing"
 	"github.com/jfrog/jfrog-cli/xray"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
@@ -80,6 +86,9 @@ Environment Variables:
 `
 
 func main() {
+	// Mask the credentials passed as options or environment variables wherever they're written.
+	redact.AddArgsSecrets(os.Args[1:])
+	redact.AddEnvSecrets()
 	log.SetDefaultLogger()
 	err := execMain()
 	if cleanupErr := fileutils.CleanOldDirs(); cleanupErr != nil {
@@ -88,7 +97,7 @@ func main() {
 	coreutils.ExitOnErr(err)
 }
 
-func execMain() error {
+func execMain() (err error) {
 	// Set JFrog CLI's user-agent on the jfrog-client-go.
 	clientutils.SetUserAgent(coreutils.GetCliUserAgent())
 
@@ -99,13 +108,64 @@ func execMain() error {
 	args := os.Args
 	app.EnableBashCompletion = true
 	app.Commands = getCommands()
+	// The commands report their result and server through the app, to be recorded in the audit log.
+	report := cliutils.GetCommandReport(app)
+	commandName := getCommandName(app.Commands, args[1:])
+	log.SetCommandName(commandName)
+	tracing.StartCommand(commandName)
+	defer func() {
+		exitCode := getExitCode(err).Code
+		if auditErr := auditutils.RecordCommand(commandName, args[1:], exitCode, err, report); auditErr != nil {
+			clientLog.Warn("Failed recording the command in the audit log: " + auditErr.Error())
+		}
+		if exportErr := tracing.EndCommand(exitCode, err); exportErr != nil {
+			clientLog.Warn("Failed exporting the command traces: " + exportErr.Error())
+		}
+	}()
 	cli.CommandHelpTemplate = commandHelpTemplate
 	cli.AppHelpTemplate = appHelpTemplate
 	cli.SubcommandHelpTemplate = subcommandHelpTemplate
-	err := app.Run(args)
+	err = app.Run(args)
 	return err
 }
 
+// Returns the exit code of the command, as determined by coreutils.ExitOnErr.
+func getExitCode(err error) coreutils.ExitCode {
+	if cliErr, ok := err.(coreutils.CliError); ok {
+		return cliErr.ExitCode
+	}
+	return coreutils.GetExitCode(err, 0, 0, false)
+}
+
+// Returns the full name of the command in the args, such as 'rt upload', resolving the aliases of the command.
+func getCommandName(commands []cli.Command, args []string) string {
+	var names []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		command := findCommand(commands, arg)
+		if command == nil {
+			break
+		}
+		names = append(names, command.Name)
+		if len(command.Subcommands) == 0 {
+			break
+		}
+		commands = command.Subcommands
+	}
+	return strings.Join(names, " ")
+}
+
+func findCommand(commands []cli.Command, name string) *cli.Command {
+	for i := range commands {
+		if commands[i].HasName(name) {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
 func getCommands() []cli.Command {
 	cliNameSpaces := []cli.Command{
 		{
@@ -144,6 +204,16 @@ func getCommands() []cli.Command {
 			Description: "Config commands",
 			Subcommands: config.GetCommands(),
 		},
+		{
+			Name:        cliutils.CmdAudit,
+			Description: "Audit log commands",
+			Subcommands: audit.GetCommands(),
+		},
+		{
+			Name:        cliutils.CmdCache,
+			Description: "Download cache commands",
+			Subcommands: cache.GetCommands(),
+		},
 
 		{
 			Name:         "ci-setup",
@@ -157,5 +227,5 @@ func getCommands() []cli.Command {
 			},
 		},
 	}
-	return append(cliNameSpaces, utils.GetPlugins()...)
+	return append(cliNameSpaces, utils.GetPlugins(cliNameSpaces)...)
 }
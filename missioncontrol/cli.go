@@ -220,6 +220,7 @@ func createMissionControlDetails(c *cli.Context) (*config.ServerDetails, error)
 	}
 
 	confDetails.Url = clientutils.AddTrailingSlashIfNeeded(confDetails.MissionControlUrl)
+	cliutils.AddServerDetailsSecrets(confDetails)
 	return confDetails, nil
 }
 
@@ -5,8 +5,14 @@ import (
 	corecommon "github.com/jfrog/jfrog-cli-core/docs/common"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	installdocs "github.com/jfrog/jfrog-cli/docs/plugin/install"
+	linkdocs "github.com/jfrog/jfrog-cli/docs/plugin/link"
+	listdocs "github.com/jfrog/jfrog-cli/docs/plugin/list"
 	publishdocs "github.com/jfrog/jfrog-cli/docs/plugin/publish"
+	rundocs "github.com/jfrog/jfrog-cli/docs/plugin/run"
+	syncdocs "github.com/jfrog/jfrog-cli/docs/plugin/sync"
 	uninstalldocs "github.com/jfrog/jfrog-cli/docs/plugin/uninstall"
+	unlinkdocs "github.com/jfrog/jfrog-cli/docs/plugin/unlink"
+	upgradedocs "github.com/jfrog/jfrog-cli/docs/plugin/upgrade"
 	"github.com/jfrog/jfrog-cli/plugins/commands"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 )
@@ -16,6 +22,7 @@ func GetCommands() []cli.Command {
 		{
 			Name:         "install",
 			Aliases:      []string{"i"},
+			Flags:        cliutils.GetCommandFlags(cliutils.PluginInstall),
 			Description:  installdocs.Description,
 			HelpName:     corecommon.CreateUsage("plugin install", installdocs.Description, installdocs.Usage),
 			UsageText:    installdocs.Arguments,
@@ -40,6 +47,7 @@ func GetCommands() []cli.Command {
 		{
 			Name:         "publish",
 			Aliases:      []string{"p"},
+			Flags:        cliutils.GetCommandFlags(cliutils.PluginPublish),
 			Description:  publishdocs.Description,
 			HelpName:     corecommon.CreateUsage("plugin publish", publishdocs.Description, publishdocs.Usage),
 			UsageText:    publishdocs.Arguments,
@@ -49,5 +57,79 @@ func GetCommands() []cli.Command {
 				return commands.PublishCmd(c)
 			},
 		},
+		{
+			Name:         "list",
+			Aliases:      []string{"l"},
+			Flags:        cliutils.GetCommandFlags(cliutils.PluginList),
+			Description:  listdocs.Description,
+			HelpName:     corecommon.CreateUsage("plugin list", listdocs.Description, listdocs.Usage),
+			ArgsUsage:    common.CreateEnvVars(listdocs.EnvVar),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return commands.ListCmd(c)
+			},
+		},
+		{
+			Name:         "upgrade",
+			Aliases:      []string{"ug"},
+			Flags:        cliutils.GetCommandFlags(cliutils.PluginUpgrade),
+			Description:  upgradedocs.Description,
+			HelpName:     corecommon.CreateUsage("plugin upgrade", upgradedocs.Description, upgradedocs.Usage),
+			UsageText:    upgradedocs.Arguments,
+			ArgsUsage:    common.CreateEnvVars(upgradedocs.EnvVar),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return commands.UpgradeCmd(c)
+			},
+		},
+		{
+			Name:         "sync",
+			Aliases:      []string{"s"},
+			Flags:        cliutils.GetCommandFlags(cliutils.PluginSync),
+			Description:  syncdocs.Description,
+			HelpName:     corecommon.CreateUsage("plugin sync", syncdocs.Description, syncdocs.Usage),
+			ArgsUsage:    common.CreateEnvVars(syncdocs.EnvVar),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return commands.SyncCmd(c)
+			},
+		},
+		{
+			Name:         "link",
+			Aliases:      []string{"ln"},
+			Description:  linkdocs.Description,
+			HelpName:     corecommon.CreateUsage("plugin link", linkdocs.Description, linkdocs.Usage),
+			UsageText:    linkdocs.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return commands.LinkCmd(c)
+			},
+		},
+		{
+			Name:         "unlink",
+			Aliases:      []string{"uln"},
+			Description:  unlinkdocs.Description,
+			HelpName:     corecommon.CreateUsage("plugin unlink", unlinkdocs.Description, unlinkdocs.Usage),
+			UsageText:    unlinkdocs.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return commands.UnlinkCmd(c)
+			},
+		},
+		{
+			Name:            "run",
+			Aliases:         []string{"r"},
+			Description:     rundocs.Description,
+			HelpName:        corecommon.CreateUsage("plugin run", rundocs.Description, rundocs.Usage),
+			UsageText:       rundocs.Arguments,
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return commands.RunCmd(c)
+			},
+		},
 	})
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/jfrog/jfrog-cli-core/utils/config"
 	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
 	clientUtils "github.com/jfrog/jfrog-client-go/utils"
 	"net/http"
 	"os"
@@ -15,6 +16,7 @@ import (
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	logUtils "github.com/jfrog/jfrog-cli/utils/log"
 	"github.com/jfrog/jfrog-cli/utils/progressbar"
+	"github.com/jfrog/jfrog-cli/utils/tracing"
 	"github.com/jfrog/jfrog-client-go/http/httpclient"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
@@ -30,10 +32,11 @@ func InstallCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	return runInstallCmd(c.Args().Get(0))
+	return runInstallCmd(c.Args().Get(0), c.Bool("verify"), "")
 }
 
-func runInstallCmd(requestedPlugin string) error {
+// If expectedSha256 is not empty, the downloaded plugin's checksum must match it.
+func runInstallCmd(requestedPlugin string, verify bool, expectedSha256 string) error {
 	pluginName, version, err := getNameAndVersion(requestedPlugin)
 	if err != nil {
 		return err
@@ -49,21 +52,24 @@ func runInstallCmd(requestedPlugin string) error {
 		return err
 	}
 
-	pluginRtPath, err := getRequiredPluginRtPath(pluginName, version)
+	downloadUrl, err := getPluginDownloadUrl(url, pluginName, version)
 	if err != nil {
 		return err
 	}
-	downloadUrl := clientUtils.AddTrailingSlashIfNeeded(url) + pluginRtPath
 
-	should, err := shouldDownloadPlugin(pluginsDir, pluginName, downloadUrl, httpDetails)
-	if err != nil {
-		return err
-	}
-	if !should {
-		return errorutils.CheckError(errors.New("the plugin with the requested version already exists locally"))
+	// A pinned checksum is requested only when the installed plugin is missing or differs from it, so the plugin is always downloaded.
+	// If the remote plugin is identical to the installed plugin, its checksum mismatch is reported after the download.
+	if expectedSha256 == "" {
+		should, err := shouldDownloadPlugin(pluginsDir, pluginName, downloadUrl, httpDetails)
+		if err != nil {
+			return err
+		}
+		if !should {
+			return errorutils.CheckError(errors.New("the plugin with the requested version already exists locally"))
+		}
 	}
 
-	return downloadPlugin(pluginsDir, pluginName, downloadUrl, httpDetails)
+	return downloadPlugin(pluginsDir, pluginName, downloadUrl, httpDetails, verify, expectedSha256)
 }
 
 // Assert repo env is not passed without server env.
@@ -112,6 +118,15 @@ func getServerDetails() (string, httputils.HttpClientDetails, error) {
 	return rtDetails.ArtifactoryUrl, commandsUtils.CreatePluginsHttpDetails(rtDetails), nil
 }
 
+// Returns the download URL of the plugin's executable corresponding to the local architecture.
+func getPluginDownloadUrl(url, pluginName, version string) (string, error) {
+	pluginRtPath, err := getRequiredPluginRtPath(pluginName, version)
+	if err != nil {
+		return "", err
+	}
+	return clientUtils.AddTrailingSlashIfNeeded(url) + pluginRtPath, nil
+}
+
 // Checks if the requested plugin exists in registry and does not exists locally.
 func shouldDownloadPlugin(pluginsDir, pluginName, downloadUrl string, httpDetails httputils.HttpClientDetails) (bool, error) {
 	exists, err := fileutils.IsFileExists(filepath.Join(pluginsDir, commandsUtils.GetLocalPluginExecutableName(pluginName)), false)
@@ -137,7 +152,7 @@ func shouldDownloadPlugin(pluginsDir, pluginName, downloadUrl string, httpDetail
 	if err != nil {
 		return false, err
 	}
-	equal, err := fileutils.IsEqualToLocalFile(filepath.Join(pluginsDir, pluginName), details.Checksum.Md5, details.Checksum.Sha1)
+	equal, err := fileutils.IsEqualToLocalFile(filepath.Join(pluginsDir, commandsUtils.GetLocalPluginExecutableName(pluginName)), details.Checksum.Md5, details.Checksum.Sha1)
 	return !equal, err
 }
 
@@ -154,13 +169,20 @@ func createPluginsDir(pluginsDir string) error {
 	return os.MkdirAll(pluginsDir, 0777)
 }
 
-func downloadPlugin(pluginsDir, pluginName, downloadUrl string, httpDetails httputils.HttpClientDetails) error {
+// Downloads the plugin to a temp dir and verifies it, before moving it to the plugins dir.
+// If expectedSha256 is not empty, the downloaded plugin's checksum must match it.
+func downloadPlugin(pluginsDir, pluginName, downloadUrl string, httpDetails httputils.HttpClientDetails, verify bool, expectedSha256 string) error {
 	exeName := commandsUtils.GetLocalPluginExecutableName(pluginName)
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
 	log.Debug("Downloading plugin from: ", downloadUrl)
 	downloadDetails := &httpclient.DownloadFileDetails{
 		FileName:      pluginName,
 		DownloadPath:  downloadUrl,
-		LocalPath:     pluginsDir,
+		LocalPath:     tmpDir,
 		LocalFileName: exeName,
 		RelativePath:  exeName,
 	}
@@ -181,7 +203,10 @@ func downloadPlugin(pluginsDir, pluginName, downloadUrl string, httpDetails http
 	}
 	log.Info("Downloading plugin: " + pluginName)
 
-	resp, err := client.DownloadFileWithProgress(downloadDetails, "", httpDetails, false, progressMgr)
+	resp, _, err := tracing.TraceHttpRequest(http.MethodGet, downloadUrl, func() (*http.Response, []byte, error) {
+		resp, err := client.DownloadFileWithProgress(downloadDetails, "", httpDetails, false, progressMgr)
+		return resp, nil, err
+	})
 	if err != nil {
 		return err
 	}
@@ -191,7 +216,27 @@ func downloadPlugin(pluginsDir, pluginName, downloadUrl string, httpDetails http
 		return err
 	}
 	log.Debug("Plugin downloaded successfully.")
-	return os.Chmod(filepath.Join(pluginsDir, exeName), 0777)
+	tmpExePath := filepath.Join(tmpDir, exeName)
+	err = verifyPlugin(tmpExePath, downloadUrl, httpDetails, verify)
+	if err != nil {
+		return err
+	}
+	if expectedSha256 != "" {
+		err = verifyExpectedSha256(tmpExePath, expectedSha256)
+		if err != nil {
+			return err
+		}
+	}
+	exePath := filepath.Join(pluginsDir, exeName)
+	err = fileutils.MoveFile(tmpExePath, exePath)
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(exePath, 0777)
+	if err != nil {
+		return err
+	}
+	return pluginsutils.InvalidateSignaturesCache()
 }
 
 func getNameAndVersion(requested string) (name, version string, err error) {
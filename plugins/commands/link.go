@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"github.com/codegangsta/cli"
+	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func LinkCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	devPlugin, err := pluginsutils.LinkDevPlugin(c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	log.Info("Development plugin '" + devPlugin.Name + "' was linked to " + devPlugin.SourcePath + ".")
+	return nil
+}
+
+func UnlinkCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	err := pluginsutils.UnlinkDevPlugin(c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	log.Info("Development plugin '" + c.Args().Get(0) + "' was unlinked.")
+	return nil
+}
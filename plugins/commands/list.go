@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/tracing"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The fields of the plugins details, which can be selected using the --fields option.
+var pluginDetailsFields = []string{"name", "localVersion", "latestVersion", "architecture", "dev"}
+
+type PluginDetails struct {
+	Name          string `json:"name"`
+	LocalVersion  string `json:"localVersion"`
+	LatestVersion string `json:"latestVersion"`
+	Architecture  string `json:"architecture"`
+	// Set for development plugins, linked using the 'plugin link' command.
+	Dev bool `json:"dev"`
+}
+
+func ListCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	outputOptions, err := cliutils.GetOutputOptions(c, cliutils.Table, pluginDetailsFields, pluginDetailsFields)
+	if err != nil {
+		return err
+	}
+	err = assertValidEnv(c)
+	if err != nil {
+		return err
+	}
+	return runListCmd(outputOptions)
+}
+
+func runListCmd(outputOptions *cliutils.OutputOptions) error {
+	pluginsDetails, err := getInstalledPluginsDetails()
+	if err != nil {
+		return err
+	}
+	if len(pluginsDetails) == 0 && outputOptions.Format == cliutils.Table {
+		log.Output("No plugins are installed.")
+		return nil
+	}
+	writer := cliutils.NewOutputWriter(outputOptions)
+	for _, details := range pluginsDetails {
+		if err = writer.Write(details); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// Returns the details of all installed plugins, including the latest version available in the plugins repository.
+// The linked development plugins are returned as well.
+func getInstalledPluginsDetails() ([]PluginDetails, error) {
+	pluginsDetails := []PluginDetails{}
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	if err != nil {
+		return pluginsDetails, err
+	}
+	pluginsNames, err := getInstalledPlugins(pluginsDir)
+	if err != nil {
+		return pluginsDetails, err
+	}
+	arc, err := commandsUtils.GetLocalArchitecture()
+	if err != nil {
+		return pluginsDetails, err
+	}
+	devPluginsDetails, err := getDevPluginsDetails(arc)
+	if err != nil || len(pluginsNames) == 0 {
+		return devPluginsDetails, err
+	}
+	url, httpDetails, err := getServerDetails()
+	if err != nil {
+		return pluginsDetails, err
+	}
+	client, err := httpclient.ClientBuilder().Build()
+	if err != nil {
+		return pluginsDetails, err
+	}
+	for _, pluginName := range pluginsNames {
+		details := PluginDetails{Name: pluginName, Architecture: arc}
+		details.LocalVersion, err = getLocalPluginVersion(pluginsDir, pluginName)
+		if err != nil {
+			log.Warn("Failed getting the local version of plugin '" + pluginName + "': " + err.Error())
+		}
+		details.LatestVersion, err = getLatestRemoteVersion(client, url, httpDetails, pluginName, arc)
+		if err != nil {
+			log.Warn("Failed getting the latest version of plugin '" + pluginName + "': " + err.Error())
+		}
+		pluginsDetails = append(pluginsDetails, details)
+	}
+	return append(pluginsDetails, devPluginsDetails...), nil
+}
+
+func getDevPluginsDetails(arc string) ([]PluginDetails, error) {
+	pluginsDetails := []PluginDetails{}
+	devDir, err := pluginsutils.GetDevPluginsDir()
+	if err != nil {
+		return pluginsDetails, err
+	}
+	// The versions are read from the executables, so the plugins which their sources were modified are rebuilt first.
+	if err = pluginsutils.RebuildDevPluginsIfNeeded(); err != nil {
+		return pluginsDetails, err
+	}
+	devPlugins, err := pluginsutils.GetDevPlugins()
+	if err != nil {
+		return pluginsDetails, err
+	}
+	for _, devPlugin := range devPlugins {
+		details := PluginDetails{Name: devPlugin.Name, Architecture: arc, Dev: true}
+		details.LocalVersion, err = getLocalPluginVersion(devDir, devPlugin.Name)
+		if err != nil {
+			log.Warn("Failed getting the local version of development plugin '" + devPlugin.Name + "': " + err.Error())
+		}
+		pluginsDetails = append(pluginsDetails, details)
+	}
+	return pluginsDetails, nil
+}
+
+// Returns the names of the installed plugins, by looping over the plugins dir.
+func getInstalledPlugins(pluginsDir string) ([]string, error) {
+	var pluginsNames []string
+	exists, err := fileutils.IsDirExists(pluginsDir, false)
+	if err != nil || !exists {
+		return pluginsNames, err
+	}
+	files, err := ioutil.ReadDir(pluginsDir)
+	if err != nil {
+		return pluginsNames, errorutils.CheckError(err)
+	}
+	for _, f := range files {
+		if f.IsDir() || f.Name() == pluginsutils.SignaturesCacheFileName {
+			continue
+		}
+		pluginsNames = append(pluginsNames, strings.TrimSuffix(f.Name(), filepath.Ext(f.Name())))
+	}
+	return pluginsNames, nil
+}
+
+// Returns the version of an installed plugin, by running the plugin's version command.
+func getLocalPluginVersion(pluginsDir, pluginName string) (string, error) {
+	pluginCmd := pluginsutils.PluginExecCmd{
+		ExecPath: filepath.Join(pluginsDir, commandsUtils.GetLocalPluginExecutableName(pluginName)),
+		Command:  []string{commandsUtils.PluginVersionCommandName},
+	}
+	output, err := io.RunCmdOutput(&pluginCmd)
+	if err != nil {
+		return "", err
+	}
+	return commandsUtils.ParsePluginVersion(output)
+}
+
+// Returns the version which the 'latest' dir of the plugin in the plugins repository was copied from.
+// The version is found by comparing the checksum of the latest executable with the executables of the published versions.
+// If several versions match, the newest of them is returned.
+func getLatestRemoteVersion(client *httpclient.HttpClient, url string, httpDetails httputils.HttpClientDetails, pluginName, arc string) (string, error) {
+	checksums, err := getRemoteChecksums(client, clientutils.AddTrailingSlashIfNeeded(url), httpDetails, pluginName, arc)
+	if err != nil {
+		return "", err
+	}
+	latestChecksum, exists := checksums[commandsUtils.LatestVersionName]
+	if !exists {
+		return "", errorutils.CheckError(errors.New("the plugin has no latest version for the " + arc + " architecture"))
+	}
+	latestVersion := ""
+	for curVersion, checksum := range checksums {
+		if curVersion == commandsUtils.LatestVersionName || checksum != latestChecksum {
+			continue
+		}
+		if latestVersion == "" || pluginsutils.CompareVersions(curVersion, latestVersion) > 0 {
+			latestVersion = curVersion
+		}
+	}
+	if latestVersion == "" {
+		return "", errorutils.CheckError(errors.New("no published version matches the latest version of the plugin"))
+	}
+	return latestVersion, nil
+}
+
+// Returns the sha1 checksums of the plugin's executables for the architecture, by the versions they're published under,
+// including the 'latest' dir. The executables of all versions are found by a single listing of the plugin's dir in the plugins repository.
+func getRemoteChecksums(client *httpclient.HttpClient, baseUrl string, httpDetails httputils.HttpClientDetails, pluginName, arc string) (map[string]string, error) {
+	listUrl := baseUrl + "api/storage/" + commandsUtils.GetPluginDirInArtifactory(pluginName) + "?list&deep=1&listFolders=0"
+	resp, body, err := tracing.TraceHttpRequest(http.MethodGet, listUrl, func() (*http.Response, []byte, error) {
+		resp, body, _, err := client.SendGet(listUrl, true, httpDetails, "")
+		return resp, body, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Artifactory response: ", resp.Status)
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return nil, errorutils.CheckError(errors.New("Artifactory response: " + resp.Status + "\n" + clientutils.IndentJson(body)))
+	}
+	fileList := struct {
+		Files []struct {
+			Uri  string `json:"uri"`
+			Sha1 string `json:"sha1"`
+		} `json:"files"`
+	}{}
+	if err = json.Unmarshal(body, &fileList); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	executableName := pluginName + commandsUtils.ArchitecturesMap[arc].FileExtension
+	checksums := make(map[string]string)
+	for _, file := range fileList.Files {
+		// Expected uri: /version/architecture/executable
+		split := strings.Split(strings.TrimPrefix(file.Uri, "/"), "/")
+		// Dirs starting with '.' are the temporary dirs used while publishing.
+		if len(split) == 3 && split[1] == arc && split[2] == executableName && !strings.HasPrefix(split[0], ".") {
+			checksums[split[0]] = file.Sha1
+		}
+	}
+	return checksums, nil
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetInstalledPlugins(t *testing.T) {
+	pluginsDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(pluginsDir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(pluginsDir, "hello-frog"), []byte{}, 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(pluginsDir, "rt-fs.exe"), []byte{}, 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(pluginsDir, pluginsutils.SignaturesCacheFileName), []byte{}, 0600))
+	assert.NoError(t, os.Mkdir(filepath.Join(pluginsDir, "dir"), 0700))
+
+	pluginsNames, err := getInstalledPlugins(pluginsDir)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"hello-frog", "rt-fs"}, pluginsNames)
+}
+
+func TestGetLatestRemoteVersion(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if !strings.HasPrefix(r.URL.Path, "/api/storage/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"files":[` +
+			`{"uri":"/v1.0.0/linux-amd64/hello-frog","sha1":"111"},` +
+			`{"uri":"/v1.0.0/windows-amd64/hello-frog.exe","sha1":"222"},` +
+			`{"uri":"/v1.1.0/linux-amd64/hello-frog","sha1":"222"},` +
+			`{"uri":"/v1.1.0/linux-amd64/hello-frog.sha256","sha1":"333"},` +
+			`{"uri":"/v1.0.1/linux-amd64/hello-frog","sha1":"222"},` +
+			`{"uri":"/latest/linux-amd64/hello-frog","sha1":"222"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := httpclient.ClientBuilder().Build()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	latestVersion, err := getLatestRemoteVersion(client, server.URL, httputils.HttpClientDetails{}, "hello-frog", "linux-amd64")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.1.0", latestVersion)
+	assert.Equal(t, 1, requests)
+
+	// The plugin wasn't published for this architecture.
+	_, err = getLatestRemoteVersion(client, server.URL, httputils.HttpClientDetails{}, "hello-frog", "linux-arm64")
+	assert.Error(t, err)
+}
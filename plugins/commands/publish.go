@@ -2,8 +2,10 @@ package commands
 
 import (
 	"errors"
+	"fmt"
 	"github.com/codegangsta/cli"
 	"github.com/jfrog/gofrog/io"
+	"github.com/jfrog/gofrog/parallel"
 	"github.com/jfrog/jfrog-cli-core/artifactory/commands/generic"
 	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
 	rtutils "github.com/jfrog/jfrog-cli-core/artifactory/utils"
@@ -11,18 +13,28 @@ import (
 	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
 	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/tracing"
 	"github.com/jfrog/jfrog-client-go/http/httpclient"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/crypto/openpgp"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 )
 
-const pluginVersionCommandName = "-v"
+// Temporary dirs in the plugin's dir, used to replace the latest dir. Dirs starting with '.' aren't taken as versions of the plugin.
+const (
+	latestStagingDirName = ".latest-staging"
+	latestBackupDirName  = ".latest-backup"
+)
 
 func PublishCmd(c *cli.Context) error {
 	if c.NArg() != 2 {
@@ -34,74 +46,152 @@ func PublishCmd(c *cli.Context) error {
 		return err
 	}
 
-	return runPublishCmd(c.Args().Get(0), c.Args().Get(1), rtDetails)
+	arcs, err := getArchitecturesToPublish(c.String("arch"))
+	if err != nil {
+		return err
+	}
+
+	threads, err := cliutils.GetIntFlagValue(c, "threads", cliutils.Threads)
+	if err != nil {
+		return err
+	}
+
+	return runPublishCmd(c.Args().Get(0), c.Args().Get(1), c.String("signing-key"), arcs, threads, rtDetails)
 }
 
-func runPublishCmd(pluginName, pluginVersion string, rtDetails *config.ServerDetails) error {
+func runPublishCmd(pluginName, pluginVersion, signingKeyPath string, arcs []string, threads int, rtDetails *config.ServerDetails) error {
 	err := verifyUniqueVersion(pluginName, pluginVersion, rtDetails)
 	if err != nil {
 		return err
 	}
 
-	return doPublish(pluginName, pluginVersion, rtDetails)
+	var signer *openpgp.Entity
+	if signingKeyPath != "" {
+		signer, err = readSigningKey(signingKeyPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return doPublish(pluginName, pluginVersion, arcs, threads, signer, rtDetails)
 }
 
-// Build and upload the plugin for every supported architecture.
-func doPublish(pluginName, pluginVersion string, rtDetails *config.ServerDetails) error {
+// Build and upload the plugin for every requested architecture.
+// A checksum manifest, and a detached signature if a signer is provided, are uploaded next to every executable.
+// The plugin is uploaded only if it was built successfully for all architectures.
+// If an upload fails, the uploaded version is deleted, and the latest dir is not updated.
+// The latest dir is updated only if the plugin is published for all the supported architectures.
+func doPublish(pluginName, pluginVersion string, arcs []string, threads int, signer *openpgp.Entity, rtDetails *config.ServerDetails) error {
 	tmpDir, err := fileutils.CreateTempDir()
 	if err != nil {
 		return err
 	}
+	defer fileutils.RemoveTempDir(tmpDir)
 
+	// Build for the local architecture first, to assert versions match before building for all architectures.
 	localArc, err := utils.GetLocalArchitecture()
 	if err != nil {
 		return err
 	}
+	if _, ok := utils.ArchitecturesMap[localArc]; !ok {
+		return errorutils.CheckError(errors.New("local architecture is not supported. Please run again on a supported machine. Aborting"))
+	}
+	localPluginPath, err := buildPlugin(pluginName, filepath.Join(tmpDir, localArc), utils.ArchitecturesMap[localArc])
+	if err != nil {
+		return err
+	}
+	err = verifyMatchingVersion(localPluginPath, pluginVersion)
+	if err != nil {
+		return err
+	}
 
-	arcs, err := getOrderedArchitectures(localArc)
+	pluginsPaths, err := buildPlugins(pluginName, tmpDir, arcs, localArc, localPluginPath, threads)
 	if err != nil {
 		return err
 	}
 
-	// Build and upload the plugin for all architectures.
-	// Start with the local architecture, to assert versions match before uploading.
 	for _, arc := range arcs {
-		pluginPath, err := buildPlugin(pluginName, tmpDir, utils.ArchitecturesMap[arc])
-		if err != nil {
-			return err
-		}
-		if arc == localArc {
-			err = verifyMatchingVersion(pluginPath, pluginVersion)
-			if err != nil {
-				return err
-			}
+		err = uploadPlugin(pluginsPaths[arc], pluginName, pluginVersion, arc, rtDetails)
+		if err == nil {
+			err = uploadVerificationFiles(pluginsPaths[arc], pluginName, pluginVersion, arc, signer, rtDetails)
 		}
-		err = uploadPlugin(pluginPath, pluginName, pluginVersion, arc, rtDetails)
 		if err != nil {
+			rollbackPublish(pluginName, pluginVersion, rtDetails)
 			return err
 		}
 	}
 
+	if len(arcs) < len(utils.ArchitecturesMap) {
+		log.Warn("The plugin was not published for all the supported architectures, so the latest dir is not updated.")
+		return nil
+	}
 	return copyToLatestDir(pluginName, pluginVersion, rtDetails)
 }
 
-// Returns a slice of all supported architectures names, starting with the local architecture.
-// If the local architecture is not supported, abort command.
-func getOrderedArchitectures(localArc string) ([]string, error) {
-	isLocalArcSupported := false
-	orderedSlice := []string{localArc}
+// Returns the requested architectures, provided as a comma separated list.
+// If no architectures are requested, all the supported architectures are returned.
+func getArchitecturesToPublish(requestedArcs string) ([]string, error) {
+	if requestedArcs == "" {
+		return getSupportedArchitectures(), nil
+	}
+	var arcs []string
+	requested := map[string]bool{}
+	for _, arc := range strings.Split(requestedArcs, ",") {
+		arc = strings.TrimSpace(arc)
+		if _, ok := utils.ArchitecturesMap[arc]; !ok {
+			return nil, errorutils.CheckError(errors.New("unsupported architecture '" + arc + "'. Supported architectures are: " + strings.Join(getSupportedArchitectures(), ", ")))
+		}
+		if !requested[arc] {
+			requested[arc] = true
+			arcs = append(arcs, arc)
+		}
+	}
+	return arcs, nil
+}
 
+func getSupportedArchitectures() []string {
+	var arcs []string
 	for arc := range utils.ArchitecturesMap {
-		if arc == localArc {
-			isLocalArcSupported = true
-			continue
-		}
-		orderedSlice = append(orderedSlice, arc)
+		arcs = append(arcs, arc)
 	}
-	if !isLocalArcSupported {
-		return nil, errorutils.CheckError(errors.New("local architecture is not supported. Please run again on a supported machine. Aborting"))
+	sort.Strings(arcs)
+	return arcs
+}
+
+// Builds the plugin for the provided architectures in parallel, using a bounded number of threads.
+// The plugin already built for the local architecture is reused.
+// Returns the paths of the built executables, mapped by architecture.
+func buildPlugins(pluginName, tmpDir string, arcs []string, localArc, localPluginPath string, threads int) (map[string]string, error) {
+	pluginsPaths := map[string]string{}
+	var mutex sync.Mutex
+	runner := parallel.NewBounedRunner(threads, false)
+	go func() {
+		defer runner.Done()
+		for _, arc := range arcs {
+			if arc == localArc {
+				continue
+			}
+			arcName := arc
+			runner.AddTask(func(int) error {
+				pluginPath, err := buildPlugin(pluginName, filepath.Join(tmpDir, arcName), utils.ArchitecturesMap[arcName])
+				if err != nil {
+					log.Error("Failed building plugin for " + arcName + ": " + err.Error())
+					return err
+				}
+				mutex.Lock()
+				defer mutex.Unlock()
+				pluginsPaths[arcName] = pluginPath
+				return nil
+			})
+		}
+	}()
+	runner.Run()
+	// The error of every failed architecture is logged by its task.
+	if errs := runner.Errors(); len(errs) > 0 {
+		return nil, errorutils.CheckError(fmt.Errorf("failed building plugin '%s' for %d of the requested architectures", pluginName, len(errs)))
 	}
-	return orderedSlice, nil
+	pluginsPaths[localArc] = localPluginPath
+	return pluginsPaths, nil
 }
 
 func verifyMatchingVersion(pluginFullPath, pluginVersion string) error {
@@ -112,7 +202,7 @@ func verifyMatchingVersion(pluginFullPath, pluginVersion string) error {
 	}
 	pluginCmd := pluginsutils.PluginExecCmd{
 		ExecPath: pluginFullPath,
-		Command:  []string{pluginVersionCommandName},
+		Command:  []string{utils.PluginVersionCommandName},
 	}
 	output, err := io.RunCmdOutput(&pluginCmd)
 	if err != nil {
@@ -121,9 +211,13 @@ func verifyMatchingVersion(pluginFullPath, pluginVersion string) error {
 	return utils.AssertPluginVersion(output, pluginVersion)
 }
 
-func buildPlugin(pluginName, tmpDir string, arc utils.Architecture) (string, error) {
+func buildPlugin(pluginName, outputDir string, arc utils.Architecture) (string, error) {
 	log.Info("Building plugin for: " + arc.Goos + "-" + arc.Goarch + "...")
-	outputPath := filepath.Join(tmpDir, pluginName+arc.FileExtension)
+	err := os.MkdirAll(outputDir, 0777)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	outputPath := filepath.Join(outputDir, pluginName+arc.FileExtension)
 	buildCmd := utils.PluginBuildCmd{
 		OutputFullPath: outputPath,
 		Env: map[string]string{
@@ -131,7 +225,7 @@ func buildPlugin(pluginName, tmpDir string, arc utils.Architecture) (string, err
 			"GOARCH": arc.Goarch,
 		},
 	}
-	err := io.RunCmd(&buildCmd)
+	err = io.RunCmd(&buildCmd)
 	if err != nil {
 		return "", errorutils.CheckError(err)
 	}
@@ -164,7 +258,9 @@ func verifyUniqueVersion(pluginName, pluginVersion string, rtDetails *config.Ser
 	url := clientutils.AddTrailingSlashIfNeeded(rtDetails.ArtifactoryUrl) + utils.GetPluginVersionDirInArtifactory(pluginName, pluginVersion)
 	httpDetails := utils.CreatePluginsHttpDetails(rtDetails)
 
-	resp, _, err := client.SendHead(url, httpDetails, "")
+	resp, _, err := tracing.TraceHttpRequest(http.MethodHead, url, func() (*http.Response, []byte, error) {
+		return client.SendHead(url, httpDetails, "")
+	})
 	if err != nil {
 		return err
 	}
@@ -178,11 +274,95 @@ func verifyUniqueVersion(pluginName, pluginVersion string, rtDetails *config.Ser
 func uploadPlugin(pluginLocalPath, pluginName, pluginVersion, arc string, rtDetails *config.ServerDetails) error {
 	targetPath := utils.GetPluginPathInArtifactory(pluginName, pluginVersion, arc)
 	log.Info("Upload plugin to: " + targetPath + "...")
+	return uploadFile(pluginLocalPath, targetPath, rtDetails)
+}
+
+// Creates and uploads the checksum manifest and the detached signature of the plugin's executable.
+func uploadVerificationFiles(pluginLocalPath, pluginName, pluginVersion, arc string, signer *openpgp.Entity, rtDetails *config.ServerDetails) error {
+	targetPath := utils.GetPluginPathInArtifactory(pluginName, pluginVersion, arc)
+	sha256, err := pluginsutils.CalcSha256(pluginLocalPath)
+	if err != nil {
+		return err
+	}
+	manifestPath := pluginLocalPath + utils.ChecksumManifestExtension
+	manifest := utils.CreateChecksumManifest(sha256, path.Base(targetPath))
+	err = ioutil.WriteFile(manifestPath, []byte(manifest), 0600)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("Upload checksum manifest to: " + targetPath + utils.ChecksumManifestExtension + "...")
+	err = uploadFile(manifestPath, targetPath+utils.ChecksumManifestExtension, rtDetails)
+	if err != nil || signer == nil {
+		return err
+	}
+
+	signaturePath := pluginLocalPath + utils.SignatureExtension
+	err = signPlugin(pluginLocalPath, signaturePath, signer)
+	if err != nil {
+		return err
+	}
+	log.Info("Upload signature to: " + targetPath + utils.SignatureExtension + "...")
+	return uploadFile(signaturePath, targetPath+utils.SignatureExtension, rtDetails)
+}
+
+// Creates an armored detached signature of the plugin's executable.
+func signPlugin(pluginLocalPath, signaturePath string, signer *openpgp.Entity) error {
+	pluginFile, err := os.Open(pluginLocalPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer pluginFile.Close()
+	signatureFile, err := os.Create(signaturePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer signatureFile.Close()
+	return errorutils.CheckError(openpgp.ArmoredDetachSign(signatureFile, signer, pluginFile, nil))
+}
+
+// Reads the armored private key used to sign the plugin's executables.
+// An encrypted key is decrypted using the passphrase provided by env.
+func readSigningKey(signingKeyPath string) (*openpgp.Entity, error) {
+	keyFile, err := os.Open(signingKeyPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer keyFile.Close()
+	entities, err := openpgp.ReadArmoredKeyRing(keyFile)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(entities) == 0 {
+		return nil, errorutils.CheckError(errors.New("the provided signing key file does not include any key"))
+	}
+	signer := entities[0]
+	if signer.PrivateKey == nil {
+		return nil, errorutils.CheckError(errors.New("the provided signing key does not include a private key"))
+	}
+	if signer.PrivateKey.Encrypted {
+		passphrase := []byte(os.Getenv(utils.PluginsSigningKeyPassphraseEnv))
+		if len(passphrase) == 0 {
+			return nil, errorutils.CheckError(errors.New("the provided signing key is encrypted. Provide its passphrase using the " + utils.PluginsSigningKeyPassphraseEnv + " env var"))
+		}
+		if err = signer.PrivateKey.Decrypt(passphrase); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		for _, subkey := range signer.Subkeys {
+			if subkey.PrivateKey != nil && subkey.PrivateKey.Encrypted {
+				if err = subkey.PrivateKey.Decrypt(passphrase); err != nil {
+					return nil, errorutils.CheckError(err)
+				}
+			}
+		}
+	}
+	return signer, nil
+}
 
+func uploadFile(localPath, targetPath string, rtDetails *config.ServerDetails) error {
 	uploadCmd := generic.NewUploadCommand()
 	uploadCmd.SetUploadConfiguration(createUploadConfiguration()).
 		SetServerDetails(rtDetails).
-		SetSpec(createUploadSpec(pluginLocalPath, targetPath))
+		SetSpec(createUploadSpec(localPath, targetPath))
 
 	err := uploadCmd.Run()
 	if err != nil {
@@ -198,20 +378,118 @@ func uploadPlugin(pluginLocalPath, pluginName, pluginVersion, arc string, rtDeta
 	return nil
 }
 
+// Deletes the partially uploaded version of the plugin.
+func rollbackPublish(pluginName, pluginVersion string, rtDetails *config.ServerDetails) {
+	log.Info("Publishing failed. Deleting the uploaded version...")
+	err := deleteDir(utils.GetPluginVersionDirInArtifactory(pluginName, pluginVersion), rtDetails)
+	if err != nil {
+		log.Error("Failed deleting the uploaded version. It should be deleted manually: " + err.Error())
+	}
+}
+
 // Copy the uploaded version to override latest dir.
+// The version is copied to a staging dir first, so that a failed copy leaves the latest dir unchanged.
+// The latest dir is then moved to a backup dir and replaced by the staging dir. If the replacement fails, the backup is restored.
 func copyToLatestDir(pluginName, pluginVersion string, rtDetails *config.ServerDetails) error {
+	versionDir := utils.GetPluginVersionDirInArtifactory(pluginName, pluginVersion)
+	latestDir := utils.GetPluginVersionDirInArtifactory(pluginName, utils.LatestVersionName)
+	stagingDir := utils.GetPluginVersionDirInArtifactory(pluginName, latestStagingDirName)
+	backupDir := utils.GetPluginVersionDirInArtifactory(pluginName, latestBackupDirName)
+	// Delete the leftovers of an interrupted publish.
+	for _, dir := range []string{stagingDir, backupDir} {
+		if err := deleteDir(dir, rtDetails); err != nil {
+			return err
+		}
+	}
+
 	log.Info("Copying version to latest dir...")
+	if err := transferDir(false, versionDir, stagingDir, rtDetails); err != nil {
+		deleteDirOrLog(stagingDir, rtDetails)
+		return err
+	}
+	if err := transferDir(true, latestDir, backupDir, rtDetails); err != nil {
+		log.Info("Replacing the latest dir failed. Restoring it...")
+		restoreLatestDir(latestDir, backupDir, rtDetails)
+		deleteDirOrLog(stagingDir, rtDetails)
+		return err
+	}
+	if err := transferDir(true, stagingDir, latestDir, rtDetails); err != nil {
+		log.Info("Replacing the latest dir failed. Restoring it...")
+		if deleteErr := deleteDir(latestDir, rtDetails); deleteErr == nil {
+			restoreLatestDir(latestDir, backupDir, rtDetails)
+		} else {
+			log.Error("Failed restoring the latest dir. It should be restored manually from " + backupDir + ": " + deleteErr.Error())
+		}
+		deleteDirOrLog(stagingDir, rtDetails)
+		return err
+	}
+	deleteDirOrLog(backupDir, rtDetails)
+	return nil
+}
+
+// Moves the files of the backup dir back to the latest dir.
+func restoreLatestDir(latestDir, backupDir string, rtDetails *config.ServerDetails) {
+	if err := transferDir(true, backupDir, latestDir, rtDetails); err != nil {
+		log.Error("Failed restoring the latest dir. It should be restored manually from " + backupDir + ": " + err.Error())
+	}
+}
+
+// Copies, or moves, the files of a dir in the plugins repository to another dir.
+func transferDir(move bool, sourceDir, targetDir string, rtDetails *config.ServerDetails) error {
+	transferSpec := createTransferSpec(sourceDir, targetDir)
+	var err error
+	var failCount int
+	if move {
+		moveCmd := generic.NewMoveCommand()
+		moveCmd.SetServerDetails(rtDetails).SetSpec(transferSpec)
+		err = moveCmd.Run()
+		failCount = moveCmd.Result().FailCount()
+	} else {
+		copyCmd := generic.NewCopyCommand()
+		copyCmd.SetServerDetails(rtDetails).SetSpec(transferSpec)
+		err = copyCmd.Run()
+		failCount = copyCmd.Result().FailCount()
+	}
+	if err == nil && failCount > 0 {
+		err = errorutils.CheckError(fmt.Errorf("failed transferring %d files from %s to %s", failCount, sourceDir, targetDir))
+	}
+	return err
+}
+
+func deleteDirOrLog(dirPath string, rtDetails *config.ServerDetails) {
+	if err := deleteDir(dirPath, rtDetails); err != nil {
+		log.Error("Failed deleting " + dirPath + ". It should be deleted manually: " + err.Error())
+	}
+}
 
-	copyCmd := generic.NewCopyCommand()
-	copyCmd.SetServerDetails(rtDetails).SetSpec(createCopySpec(pluginName, pluginVersion))
-	return copyCmd.Run()
+// Deletes a dir from the plugins repository. A dir which doesn't exist is ignored.
+func deleteDir(dirPath string, rtDetails *config.ServerDetails) error {
+	client, err := httpclient.ClientBuilder().Build()
+	if err != nil {
+		return err
+	}
+	url := clientutils.AddTrailingSlashIfNeeded(rtDetails.ArtifactoryUrl) + dirPath
+	httpDetails := utils.CreatePluginsHttpDetails(rtDetails)
+	resp, body, err := tracing.TraceHttpRequest(http.MethodDelete, url, func() (*http.Response, []byte, error) {
+		return client.SendDelete(url, nil, httpDetails, "")
+	})
+	if err != nil {
+		return err
+	}
+	log.Debug("Artifactory response: ", resp.Status)
+	if err = errorutils.CheckResponseStatus(resp, http.StatusNoContent, http.StatusOK, http.StatusNotFound); err != nil {
+		if len(body) > 0 {
+			log.Debug(clientutils.IndentJson(body))
+		}
+		return errorutils.CheckError(errors.New("failed deleting " + url + ": " + err.Error()))
+	}
+	return nil
 }
 
-func createCopySpec(pluginName, pluginVersion string) *spec.SpecFiles {
-	pluginsRepo := utils.GetPluginsRepo()
+func createTransferSpec(sourceDir, targetDir string) *spec.SpecFiles {
 	return spec.NewBuilder().
-		Pattern(path.Join(pluginsRepo, pluginName, pluginVersion, "(*)")).
-		Target(path.Join(pluginsRepo, pluginName, utils.LatestVersionName, "{1}")).
+		Pattern(path.Join(sourceDir, "(*)")).
+		Target(path.Join(targetDir, "{1}")).
 		Flat(true).
 		Recursive(true).
 		IncludeDirs(true).
@@ -1,29 +1,115 @@
 package commands
 
 import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/utils/config"
 	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
 	"github.com/stretchr/testify/assert"
-	"testing"
+	"golang.org/x/crypto/openpgp/armor"
 )
 
-func TestGetOrderedArchitectures(t *testing.T) {
-	localArc, err := utils.GetLocalArchitecture()
+func TestGetArchitecturesToPublish(t *testing.T) {
+	// Assert all architectures are returned by default.
+	arcs, err := getArchitecturesToPublish("")
 	if err != nil {
 		assert.NoError(t, err)
 		return
 	}
+	assert.Len(t, arcs, len(utils.ArchitecturesMap))
 
-	// Assert ordering successful.
-	ordered, err := getOrderedArchitectures(localArc)
+	// Assert requested architectures are returned without duplicates.
+	arcs, err = getArchitecturesToPublish("linux-amd64, freebsd-amd64,linux-amd64")
 	if err != nil {
 		assert.NoError(t, err)
 		return
 	}
-	assert.Len(t, ordered, len(utils.ArchitecturesMap))
-	assert.Equal(t, localArc, ordered[0])
+	assert.Equal(t, []string{"linux-amd64", "freebsd-amd64"}, arcs)
 
-	// Assert ordering fails for unsupported architecture.
-	_, err = getOrderedArchitectures("made-up-arc")
+	// Assert unsupported architectures fail.
+	_, err = getArchitecturesToPublish("linux-amd64,made-up-arc")
 	assert.Error(t, err)
+}
 
+func TestReadSigningKeyWithoutKeys(t *testing.T) {
+	// An armored key block which doesn't include any key.
+	keyBlock := new(bytes.Buffer)
+	writer, err := armor.Encode(keyBlock, "PGP PRIVATE KEY BLOCK", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, writer.Close())
+	keyFile, err := ioutil.TempFile("", "signing-key")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.Remove(keyFile.Name())
+	_, err = keyFile.Write(keyBlock.Bytes())
+	assert.NoError(t, err)
+	assert.NoError(t, keyFile.Close())
+
+	_, err = readSigningKey(keyFile.Name())
+	assert.Error(t, err)
+}
+
+func TestCopyToLatestDir(t *testing.T) {
+	versionDir := utils.GetPluginVersionDirInArtifactory("hello-frog", "v1.0.0")
+	latestDir := utils.GetPluginVersionDirInArtifactory("hello-frog", utils.LatestVersionName)
+	stagingDir := utils.GetPluginVersionDirInArtifactory("hello-frog", latestStagingDirName)
+	backupDir := utils.GetPluginVersionDirInArtifactory("hello-frog", latestBackupDirName)
+	tests := []struct {
+		name string
+		// The dir whose files fail to be copied or moved.
+		failingDir string
+		// The dirs whose files are expected to be copied or moved, in order.
+		expectedTransfers []string
+	}{
+		{"success", "", []string{"copy " + versionDir, "move " + latestDir, "move " + stagingDir}},
+		// The latest dir isn't touched if the copy fails.
+		{"copyFailure", stagingDir, []string{"copy " + versionDir}},
+		// The latest dir is restored from the backup if the staging dir fails to replace it.
+		{"replaceFailure", latestDir, []string{"copy " + versionDir, "move " + latestDir, "move " + stagingDir, "move " + backupDir}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var transfers []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodDelete:
+					w.WriteHeader(http.StatusNoContent)
+				case strings.HasPrefix(r.URL.Path, "/api/search/aql"):
+					// Return a file from the searched dir.
+					body, _ := ioutil.ReadAll(r.Body)
+					dir := regexp.MustCompile(`"path":\{"\$match":"([^"*]+?)(?:/\*)?"`).FindSubmatch(body)
+					if dir == nil {
+						w.Write([]byte(`{"results":[]}`))
+						return
+					}
+					w.Write([]byte(`{"results":[{"repo":"` + utils.GetPluginsRepo() + `","path":"` + string(dir[1]) + `/linux-amd64","name":"hello-frog","type":"file"}]}`))
+				case strings.HasPrefix(r.URL.Path, "/api/copy/"), strings.HasPrefix(r.URL.Path, "/api/move/"):
+					split := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/api/"), "/", 2)
+					sourceDir := path.Dir(path.Dir(split[1]))
+					transfers = append(transfers, split[0]+" "+sourceDir)
+					if test.failingDir != "" && strings.HasPrefix(r.URL.Query().Get("to"), test.failingDir+"/") {
+						w.WriteHeader(http.StatusInternalServerError)
+					}
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			err := copyToLatestDir("hello-frog", "v1.0.0", &config.ServerDetails{ArtifactoryUrl: server.URL + "/"})
+			assert.Equal(t, test.failingDir != "", err != nil)
+			assert.Equal(t, test.expectedTransfers, transfers)
+		})
+	}
 }
@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"github.com/codegangsta/cli"
+	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+)
+
+func RunCmd(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	signature, err := pluginsutils.GetPluginSignature(c.Args().First())
+	if err != nil {
+		return err
+	}
+	return pluginsutils.ExecPlugin(signature.ExecutablePath, c.Args().Tail())
+}
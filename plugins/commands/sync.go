@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func SyncCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	err := assertValidEnv(c)
+	if err != nil {
+		return err
+	}
+	return runSyncCmd(c.Bool("verify"), c.Bool("update-lock"))
+}
+
+// Installs the plugins pinned in the project's plugins lock file, which are missing or differ from the installed plugins.
+// If updateLock is true, checksums which are not yet pinned for the local architecture are added to the lock file.
+func runSyncCmd(verify, updateLock bool) error {
+	lockPath, err := pluginsutils.GetPluginsLockPath()
+	if err != nil {
+		return err
+	}
+	if lockPath == "" {
+		return errorutils.CheckError(errors.New("no plugins lock file was found. The lock file should be located at .jfrog/" + pluginsutils.PluginsLockFileName +
+			" in the current directory or one of its parents"))
+	}
+	log.Info("Syncing plugins according to:", lockPath)
+	lock, err := pluginsutils.ReadPluginsLock(lockPath)
+	if err != nil {
+		return err
+	}
+	arc, err := commandsUtils.GetLocalArchitecture()
+	if err != nil {
+		return err
+	}
+	pluginsDir, err := createPluginsDirIfNeeded()
+	if err != nil {
+		return err
+	}
+
+	lockModified := false
+	var unpinned []string
+	var finalErr error
+	for _, pinned := range lock.Plugins {
+		modified, err := syncPlugin(pluginsDir, pinned, arc, verify, updateLock)
+		if err != nil {
+			finalErr = err
+			log.Error("Failed syncing plugin '" + pinned.Name + "': " + err.Error())
+			continue
+		}
+		lockModified = lockModified || modified
+		if pinned.GetSha256(arc) == "" {
+			unpinned = append(unpinned, pinned.Name)
+		}
+	}
+	if len(unpinned) > 0 {
+		log.Warn("No " + arc + " checksum is pinned for the following plugins, so they were synced by version only: " + strings.Join(unpinned, ", ") +
+			". Run 'jfrog plugin sync --update-lock' to pin the checksums of the installed plugins in " + lockPath + ".")
+	}
+	if lockModified {
+		log.Info("Adding the missing plugins checksums to:", lockPath)
+		if err = pluginsutils.WritePluginsLock(lockPath, lock); err != nil {
+			return err
+		}
+	}
+	return finalErr
+}
+
+// Installs the pinned plugin if needed.
+// Returns true if the checksum of the plugin was added to the pinned plugin, which happens only if updateLock is true.
+func syncPlugin(pluginsDir string, pinned *pluginsutils.LockedPlugin, arc string, verify, updateLock bool) (bool, error) {
+	expectedSha256 := pinned.GetSha256(arc)
+	should, err := shouldSyncPlugin(pluginsDir, pinned, expectedSha256)
+	if err != nil {
+		return false, err
+	}
+	if should {
+		if err = runInstallCmd(pinned.Name+"@"+pinned.Version, verify, expectedSha256); err != nil {
+			return false, err
+		}
+		log.Info("Plugin '" + pinned.Name + "' was synced to version " + pinned.Version + ".")
+	} else {
+		log.Info("Plugin '" + pinned.Name + "' is already synced.")
+	}
+	if expectedSha256 != "" || !updateLock {
+		return false, nil
+	}
+	sha256, err := pluginsutils.CalcSha256(filepath.Join(pluginsDir, commandsUtils.GetLocalPluginExecutableName(pinned.Name)))
+	if err != nil {
+		return false, err
+	}
+	pinned.SetSha256(arc, sha256)
+	return true, nil
+}
+
+// Checks if the installed plugin is missing or differs from the pinned plugin.
+// If no checksum is pinned for the local architecture, the version of the installed plugin is compared instead.
+func shouldSyncPlugin(pluginsDir string, pinned *pluginsutils.LockedPlugin, expectedSha256 string) (bool, error) {
+	exePath := filepath.Join(pluginsDir, commandsUtils.GetLocalPluginExecutableName(pinned.Name))
+	exists, err := fileutils.IsFileExists(exePath, false)
+	if err != nil || !exists {
+		return !exists, err
+	}
+	if expectedSha256 != "" {
+		actualSha256, err := pluginsutils.CalcSha256(exePath)
+		return actualSha256 != expectedSha256, err
+	}
+	localVersion, err := getLocalPluginVersion(pluginsDir, pinned.Name)
+	if err != nil {
+		log.Debug("Failed getting the version of plugin '" + pinned.Name + "': " + err.Error())
+		return true, nil
+	}
+	return pluginsutils.CompareVersions(localVersion, pinned.Version) != 0, nil
+}
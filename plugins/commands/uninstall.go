@@ -5,6 +5,7 @@ import (
 	"github.com/codegangsta/cli"
 	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
 	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -43,7 +44,11 @@ func runUninstallCmd(requestedPlugin string) error {
 			return nil
 		}
 	}
-	return os.Remove(pluginExePath)
+	err = os.Remove(pluginExePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return pluginsutils.InvalidateSignaturesCache()
 }
 
 func generateNoPluginFoundError(pluginName string) error {
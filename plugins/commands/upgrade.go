@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"path/filepath"
+)
+
+func UpgradeCmd(c *cli.Context) error {
+	upgradeAll := c.Bool("all")
+	if (upgradeAll && c.NArg() != 0) || (!upgradeAll && c.NArg() != 1) {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	err := assertValidEnv(c)
+	if err != nil {
+		return err
+	}
+	if upgradeAll {
+		pluginsDir, err := coreutils.GetJfrogPluginsDir()
+		if err != nil {
+			return err
+		}
+		pluginsNames, err := getInstalledPlugins(pluginsDir)
+		if err != nil {
+			return err
+		}
+		return runUpgradeCmd(pluginsNames, c.Bool("verify"))
+	}
+	return runUpgradeCmd([]string{c.Args().Get(0)}, c.Bool("verify"))
+}
+
+// Upgrades the provided installed plugins to their latest version.
+// Only plugins which their executable differs from the latest one in registry are downloaded.
+func runUpgradeCmd(pluginsNames []string, verify bool) error {
+	if len(pluginsNames) == 0 {
+		log.Info("No plugins are installed.")
+		return nil
+	}
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	if err != nil {
+		return err
+	}
+	url, httpDetails, err := getServerDetails()
+	if err != nil {
+		return err
+	}
+
+	var finalErr error
+	for _, pluginName := range pluginsNames {
+		exists, err := fileutils.IsFileExists(filepath.Join(pluginsDir, commandsUtils.GetLocalPluginExecutableName(pluginName)), false)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return generateNoPluginFoundError(pluginName)
+		}
+		downloadUrl, err := getPluginDownloadUrl(url, pluginName, commandsUtils.LatestVersionName)
+		if err != nil {
+			return err
+		}
+		should, err := shouldDownloadPlugin(pluginsDir, pluginName, downloadUrl, httpDetails)
+		if err != nil {
+			finalErr = err
+			log.Error("Failed upgrading plugin '" + pluginName + "': " + err.Error())
+			continue
+		}
+		if !should {
+			log.Info("Plugin '" + pluginName + "' is already up to date.")
+			continue
+		}
+		if err = downloadPlugin(pluginsDir, pluginName, downloadUrl, httpDetails, verify, ""); err != nil {
+			finalErr = err
+			log.Error("Failed upgrading plugin '" + pluginName + "': " + err.Error())
+			continue
+		}
+		log.Info("Plugin '" + pluginName + "' was upgraded successfully.")
+	}
+	return finalErr
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"runtime"
 	"strings"
 )
@@ -27,8 +28,20 @@ const (
 	PluginsOfficialRegistryUrl = "https://releases.jfrog.io/artifactory/"
 
 	LatestVersionName = "latest"
+	// The command which prints the version of a plugin.
+	PluginVersionCommandName = "-v"
+
+	// Used to decrypt the private key provided to the 'publish' command for signing the plugin's executables.
+	PluginsSigningKeyPassphraseEnv = "JFROG_CLI_PLUGINS_SIGNING_KEY_PASSPHRASE"
+	// An armored keyring, stored in the JFrog security dir, holding the public keys trusted to sign plugins.
+	PluginsTrustedKeyringFileName = "plugins-trusted-keys.asc"
+
+	// Extensions of the verification files uploaded next to every plugin's executable.
+	ChecksumManifestExtension = ".sha256"
+	SignatureExtension        = ".asc"
 )
 
+// The architectures are part of the paths of the published plugins, so their keys can't be changed.
 var ArchitecturesMap = map[string]Architecture{
 	"linux-386":     {"linux", "386", ""},
 	"linux-amd64":   {"linux", "amd64", ""},
@@ -39,6 +52,8 @@ var ArchitecturesMap = map[string]Architecture{
 	"linux-ppc64le": {"linux", "ppc64le", ""},
 	"mac-386":       {"darwin", "amd64", ""},
 	"windows-amd64": {"windows", "amd64", ".exe"},
+	"freebsd-amd64": {"freebsd", "amd64", ""},
+	"freebsd-arm64": {"freebsd", "arm64", ""},
 }
 
 func GetLocalPluginExecutableName(pluginName string) string {
@@ -54,9 +69,37 @@ func GetPluginPathInArtifactory(pluginName, pluginVersion, architecture string)
 	return path.Join(GetPluginVersionDirInArtifactory(pluginName, pluginVersion), architecture, pluginName+ArchitecturesMap[architecture].FileExtension)
 }
 
+// Returns the path of the keyring holding the public keys trusted to sign plugins.
+func GetPluginsTrustedKeyringPath() (string, error) {
+	securityDir, err := coreutils.GetJfrogSecurityDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(securityDir, PluginsTrustedKeyringFileName), nil
+}
+
+// Creates the content of a checksum manifest, in the format of the 'sha256sum' tool.
+func CreateChecksumManifest(sha256, executableName string) string {
+	return sha256 + "  " + executableName + "\n"
+}
+
+// Returns the sha256 checksum stored in a checksum manifest.
+func ParseChecksumManifest(manifest string) (string, error) {
+	fields := strings.Fields(manifest)
+	if len(fields) == 0 || len(fields[0]) != 64 {
+		return "", errorutils.CheckError(errors.New("unexpected checksum manifest content: '" + manifest + "'"))
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
 // Example path: "repo-name/plugin-name/v1.0.0/"
 func GetPluginVersionDirInArtifactory(pluginName, pluginVersion string) string {
-	return path.Join(GetPluginsRepo(), pluginName, pluginVersion)
+	return path.Join(GetPluginDirInArtifactory(pluginName), pluginVersion)
+}
+
+// Example path: "repo-name/plugin-name/"
+func GetPluginDirInArtifactory(pluginName string) string {
+	return path.Join(GetPluginsRepo(), pluginName)
 }
 
 // Returns a custom plugins repo if provided, default otherwise.
@@ -81,6 +124,14 @@ func GetLocalArchitecture() (string, error) {
 		return "windows-amd64", nil
 	case "darwin":
 		return "mac-386", nil
+	case "freebsd":
+		switch runtime.GOARCH {
+		case "amd64":
+			return "freebsd-amd64", nil
+		case "arm64":
+			return "freebsd-arm64", nil
+		}
+		return "", errorutils.CheckError(errors.New("no compatible plugin architecture was found for the architecture of this machine"))
 	}
 	// Assuming linux.
 	switch runtime.GOARCH {
@@ -114,18 +165,27 @@ func CreatePluginsHttpDetails(rtDetails *config.ServerDetails) httputils.HttpCli
 
 // Asserts a plugin's version is as expected, by parsing the output of the version command.
 func AssertPluginVersion(versionCmdOut string, expectedPluginVersion string) error {
-	// Get the actual version which is after the last space. (expected output to -v for example: "plugin-name version v1.0.0")
-	split := strings.Split(strings.TrimSpace(versionCmdOut), " ")
-	if len(split) != 3 {
-		return errorutils.CheckError(errors.New("failed verifying plugin version. Unexpected plugin output for version command: '" + versionCmdOut + "'"))
+	actualVersion, err := ParsePluginVersion(versionCmdOut)
+	if err != nil {
+		return err
 	}
-	if split[2] != expectedPluginVersion {
+	if actualVersion != expectedPluginVersion {
 		return errorutils.CheckError(errors.New("provided version does not match the plugin's actual version. " +
-			"Provided: '" + expectedPluginVersion + "', Actual: '" + split[2] + "'"))
+			"Provided: '" + expectedPluginVersion + "', Actual: '" + actualVersion + "'"))
 	}
 	return nil
 }
 
+// Returns a plugin's version, by parsing the output of the version command.
+func ParsePluginVersion(versionCmdOut string) (string, error) {
+	// Get the actual version which is after the last space. (expected output to -v for example: "plugin-name version v1.0.0")
+	split := strings.Split(strings.TrimSpace(versionCmdOut), " ")
+	if len(split) != 3 {
+		return "", errorutils.CheckError(errors.New("failed verifying plugin version. Unexpected plugin output for version command: '" + versionCmdOut + "'"))
+	}
+	return split[2], nil
+}
+
 // Command used to build plugins.
 type PluginBuildCmd struct {
 	OutputFullPath string
@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"os"
+
+	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
+	"github.com/jfrog/jfrog-cli/utils/tracing"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/crypto/openpgp"
+)
+
+// Verifies a downloaded plugin executable, using the checksum manifest and the detached signature published next to it.
+// A checksum mismatch or an invalid signature always fail the verification.
+// If failClosed is true, a missing checksum manifest, signature or trusted keyring also fail the verification.
+// Otherwise, only a warning is logged.
+func verifyPlugin(exePath, downloadUrl string, httpDetails httputils.HttpClientDetails, failClosed bool) error {
+	log.Info("Verifying plugin integrity...")
+	client, err := httpclient.ClientBuilder().Build()
+	if err != nil {
+		return err
+	}
+	if err = verifyChecksumManifest(client, exePath, downloadUrl, httpDetails, failClosed); err != nil {
+		return err
+	}
+	return verifySignature(client, exePath, downloadUrl, httpDetails, failClosed)
+}
+
+func verifyChecksumManifest(client *httpclient.HttpClient, exePath, downloadUrl string, httpDetails httputils.HttpClientDetails, failClosed bool) error {
+	manifest, err := getVerificationFile(client, downloadUrl+commandsUtils.ChecksumManifestExtension, httpDetails)
+	if err != nil {
+		return err
+	}
+	if manifest == nil {
+		return handleMissingVerification("no checksum manifest was published for the plugin", failClosed)
+	}
+	expectedSha256, err := commandsUtils.ParseChecksumManifest(string(manifest))
+	if err != nil {
+		return err
+	}
+	actualSha256, err := pluginsutils.CalcSha256(exePath)
+	if err != nil {
+		return err
+	}
+	if actualSha256 != expectedSha256 {
+		return errorutils.CheckError(errors.New("plugin verification failed: the downloaded plugin's sha256 checksum '" + actualSha256 +
+			"' does not match the published checksum '" + expectedSha256 + "'"))
+	}
+	log.Debug("Plugin checksum verified successfully.")
+	return nil
+}
+
+// Verifies the plugin executable's checksum matches the checksum pinned in the plugins lock file.
+func verifyExpectedSha256(exePath, expectedSha256 string) error {
+	actualSha256, err := pluginsutils.CalcSha256(exePath)
+	if err != nil {
+		return err
+	}
+	if actualSha256 != expectedSha256 {
+		return errorutils.CheckError(errors.New("plugin verification failed: the downloaded plugin's sha256 checksum '" + actualSha256 +
+			"' does not match the checksum '" + expectedSha256 + "' pinned in the plugins lock file"))
+	}
+	return nil
+}
+
+func verifySignature(client *httpclient.HttpClient, exePath, downloadUrl string, httpDetails httputils.HttpClientDetails, failClosed bool) error {
+	signature, err := getVerificationFile(client, downloadUrl+commandsUtils.SignatureExtension, httpDetails)
+	if err != nil {
+		return err
+	}
+	if signature == nil {
+		return handleMissingVerification("no signature was published for the plugin", failClosed)
+	}
+	keyring, err := readTrustedKeyring()
+	if err != nil {
+		return err
+	}
+	if keyring == nil {
+		keyringPath, err := commandsUtils.GetPluginsTrustedKeyringPath()
+		if err != nil {
+			return err
+		}
+		return handleMissingVerification("the plugin is signed, but no trusted keyring was found at "+keyringPath, failClosed)
+	}
+	exeFile, err := os.Open(exePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer exeFile.Close()
+	signer, err := openpgp.CheckArmoredDetachedSignature(keyring, exeFile, bytes.NewReader(signature))
+	if err != nil {
+		return errorutils.CheckError(errors.New("plugin verification failed: invalid plugin signature: " + err.Error()))
+	}
+	for name := range signer.Identities {
+		log.Debug("Plugin signature verified successfully. Signed by: " + name)
+	}
+	return nil
+}
+
+// Reads the keyring holding the public keys trusted to sign plugins.
+// Returns nil if the keyring does not exist.
+func readTrustedKeyring() (openpgp.EntityList, error) {
+	keyringPath, err := commandsUtils.GetPluginsTrustedKeyringPath()
+	if err != nil {
+		return nil, err
+	}
+	exists, err := fileutils.IsFileExists(keyringPath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	keyringFile, err := os.Open(keyringPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer keyringFile.Close()
+	keyring, err := openpgp.ReadArmoredKeyRing(keyringFile)
+	return keyring, errorutils.CheckError(err)
+}
+
+// Downloads the content of a verification file published next to the plugin.
+// Returns nil if the file does not exist.
+func getVerificationFile(client *httpclient.HttpClient, url string, httpDetails httputils.HttpClientDetails) ([]byte, error) {
+	log.Debug("Fetching plugin verification file from: ", url)
+	resp, body, err := tracing.TraceHttpRequest(http.MethodGet, url, func() (*http.Response, []byte, error) {
+		resp, body, _, err := client.SendGet(url, true, httpDetails, "")
+		return resp, body, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Artifactory response: ", resp.Status)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func handleMissingVerification(msg string, failClosed bool) error {
+	if failClosed {
+		return errorutils.CheckError(errors.New("plugin verification failed: " + msg))
+	}
+	log.Warn(msg + ". Skipping this verification. Use the --verify option to fail in this case.")
+	return nil
+}
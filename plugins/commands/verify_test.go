@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	coreTests "github.com/jfrog/jfrog-cli-core/utils/tests"
+	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+func TestVerifyPlugin(t *testing.T) {
+	// Clean from previous tests.
+	coreTests.CleanUnitTestsJfrogHome()
+	// Create temp jfrog home
+	oldHome, err := coreTests.SetJfrogHome()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+	defer coreTests.CleanUnitTestsJfrogHome()
+
+	signer, err := openpgp.NewEntity("plugins-publisher", "", "publisher@plugins.com", nil)
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+
+	// Create the verification files, as done by the publish command.
+	signaturePath := filepath.Join(tmpDir, "plugin-mock"+utils.SignatureExtension)
+	if !assert.NoError(t, signPlugin(pluginMockPath, signaturePath, signer)) {
+		return
+	}
+	signature, err := ioutil.ReadFile(signaturePath)
+	assert.NoError(t, err)
+	sha256, err := pluginsutils.CalcSha256(pluginMockPath)
+	assert.NoError(t, err)
+	files := map[string]string{
+		"/plugin-mock" + utils.ChecksumManifestExtension: utils.CreateChecksumManifest(sha256, "plugin-mock"),
+		"/plugin-mock" + utils.SignatureExtension:        string(signature),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+	downloadUrl := server.URL + "/plugin-mock"
+
+	// The plugin is signed, but no trusted keyring exists.
+	assert.Error(t, verifyPlugin(pluginMockPath, downloadUrl, httputils.HttpClientDetails{}, true))
+	assert.NoError(t, verifyPlugin(pluginMockPath, downloadUrl, httputils.HttpClientDetails{}, false))
+
+	// Trust the signer's public key.
+	if !assert.NoError(t, writeTrustedKeyring(signer)) {
+		return
+	}
+	assert.NoError(t, verifyPlugin(pluginMockPath, downloadUrl, httputils.HttpClientDetails{}, true))
+
+	// A modified executable should always fail the verification.
+	modifiedPluginPath := filepath.Join(tmpDir, "plugin-mock")
+	assert.NoError(t, ioutil.WriteFile(modifiedPluginPath, []byte("This is a modified plugin mock."), 0700))
+	assert.Error(t, verifyPlugin(modifiedPluginPath, downloadUrl, httputils.HttpClientDetails{}, false))
+
+	// A missing signature should fail the verification only when failing closed.
+	delete(files, "/plugin-mock"+utils.SignatureExtension)
+	assert.Error(t, verifyPlugin(pluginMockPath, downloadUrl, httputils.HttpClientDetails{}, true))
+	assert.NoError(t, verifyPlugin(pluginMockPath, downloadUrl, httputils.HttpClientDetails{}, false))
+}
+
+func writeTrustedKeyring(entity *openpgp.Entity) error {
+	keyringPath, err := utils.GetPluginsTrustedKeyringPath()
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(keyringPath), 0700); err != nil {
+		return err
+	}
+	buf := new(bytes.Buffer)
+	writer, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return err
+	}
+	if err = entity.Serialize(writer); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(keyringPath, buf.Bytes(), 0600)
+}
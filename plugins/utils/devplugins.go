@@ -0,0 +1,266 @@
+package utils
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Development plugins are linked to their local sources, and are rebuilt when run or listed after their sources change.
+	// Their executables are stored in this dir, under the JFrog home dir, along with the links file and a signatures cache.
+	DevPluginsDirName       = "dev-plugins"
+	devPluginsLinksFileName = ".links.json"
+)
+
+// Matches the major version suffix of a Go module path, such as 'v2'.
+var majorVersionSuffixRegExp = regexp.MustCompile(`^v[0-9]+$`)
+
+// A development plugin, linked to the Go module holding its sources.
+type DevPlugin struct {
+	Name       string `json:"name"`
+	SourcePath string `json:"sourcePath"`
+}
+
+func GetDevPluginsDir() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, DevPluginsDirName), nil
+}
+
+// Returns the linked development plugins.
+func GetDevPlugins() ([]*DevPlugin, error) {
+	devDir, err := GetDevPluginsDir()
+	if err != nil {
+		return nil, err
+	}
+	return readDevPluginsLinks(devDir)
+}
+
+func readDevPluginsLinks(devDir string) ([]*DevPlugin, error) {
+	content, err := ioutil.ReadFile(filepath.Join(devDir, devPluginsLinksFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	var devPlugins []*DevPlugin
+	return devPlugins, errorutils.CheckError(json.Unmarshal(content, &devPlugins))
+}
+
+func writeDevPluginsLinks(devDir string, devPlugins []*DevPlugin) error {
+	content, err := json.Marshal(devPlugins)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(filepath.Join(devDir, devPluginsLinksFileName), content, 0600))
+}
+
+// Links the Go module in the provided path as a development plugin, and builds it.
+// The plugin is named after the last element of the module path.
+func LinkDevPlugin(sourcePath string) (*DevPlugin, error) {
+	sourcePath, err := filepath.Abs(sourcePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	pluginName, err := getModulePluginName(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	devDir, err := GetDevPluginsDir()
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(devDir, 0777); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	devPlugins, err := readDevPluginsLinks(devDir)
+	if err != nil {
+		return nil, err
+	}
+	devPlugin := &DevPlugin{Name: pluginName, SourcePath: sourcePath}
+	for i, linked := range devPlugins {
+		if linked.Name == pluginName {
+			log.Info("Replacing the link of plugin '" + pluginName + "' to " + linked.SourcePath + ".")
+			devPlugins = append(devPlugins[:i], devPlugins[i+1:]...)
+			break
+		}
+	}
+	if err = buildDevPlugin(devPlugin, getDevPluginExecutablePath(devDir, pluginName)); err != nil {
+		return nil, err
+	}
+	return devPlugin, writeDevPluginsLinks(devDir, append(devPlugins, devPlugin))
+}
+
+// Removes the link of a development plugin, along with its executable.
+func UnlinkDevPlugin(pluginName string) error {
+	devDir, err := GetDevPluginsDir()
+	if err != nil {
+		return err
+	}
+	devPlugins, err := readDevPluginsLinks(devDir)
+	if err != nil {
+		return err
+	}
+	for i, linked := range devPlugins {
+		if linked.Name != pluginName {
+			continue
+		}
+		if err = writeDevPluginsLinks(devDir, append(devPlugins[:i], devPlugins[i+1:]...)); err != nil {
+			return err
+		}
+		exePath := getDevPluginExecutablePath(devDir, pluginName)
+		exists, err := fileutils.IsFileExists(exePath, false)
+		if err != nil || !exists {
+			return err
+		}
+		return errorutils.CheckError(os.Remove(exePath))
+	}
+	return errorutils.CheckError(errors.New("no development plugin named '" + pluginName + "' is linked"))
+}
+
+func getDevPluginExecutablePath(devDir, pluginName string) string {
+	return filepath.Join(devDir, commandsUtils.GetLocalPluginExecutableName(pluginName))
+}
+
+// Returns the last element of the path of the Go module in the provided dir, ignoring a major version suffix.
+func getModulePluginName(sourcePath string) (string, error) {
+	goModFile, err := os.Open(filepath.Join(sourcePath, "go.mod"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errorutils.CheckError(errors.New(sourcePath + " is not a Go module. A go.mod file is expected in the plugin's root dir"))
+		}
+		return "", errorutils.CheckError(err)
+	}
+	defer goModFile.Close()
+	scanner := bufio.NewScanner(goModFile)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "module" {
+			continue
+		}
+		modulePath := strings.Trim(fields[1], "\"`")
+		name := path.Base(modulePath)
+		if majorVersionSuffixRegExp.MatchString(name) && path.Dir(modulePath) != "." {
+			name = path.Base(path.Dir(modulePath))
+		}
+		return name, nil
+	}
+	if err = scanner.Err(); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return "", errorutils.CheckError(errors.New("no module path was found in " + goModFile.Name()))
+}
+
+// Rebuilds the linked development plugins, which their sources were modified after their executables were built.
+// Development plugins are rebuilt lazily, when they are executed or listed, and not while loading the CLI's commands.
+func RebuildDevPluginsIfNeeded() error {
+	devDir, err := GetDevPluginsDir()
+	if err != nil {
+		return err
+	}
+	devPlugins, err := readDevPluginsLinks(devDir)
+	if err != nil {
+		return err
+	}
+	for _, devPlugin := range devPlugins {
+		rebuildDevPluginIfNeeded(devDir, devPlugin)
+	}
+	return nil
+}
+
+// Rebuilds the development plugin of the provided executable, if it is linked and its sources were modified.
+// Executables of installed plugins are ignored.
+func rebuildDevPluginByExecutableIfNeeded(execPath string) {
+	devDir, err := GetDevPluginsDir()
+	if err != nil || filepath.Dir(execPath) != devDir {
+		return
+	}
+	devPlugins, err := readDevPluginsLinks(devDir)
+	if err != nil {
+		log.Warn(pluginsErrorPrefix + "failed reading the development plugins links: " + err.Error())
+		return
+	}
+	pluginName := getPluginNameFromPath(execPath)
+	for _, devPlugin := range devPlugins {
+		if devPlugin.Name == pluginName {
+			rebuildDevPluginIfNeeded(devDir, devPlugin)
+			return
+		}
+	}
+}
+
+// Rebuilds the development plugin, if its sources were modified after its executable was built.
+// A plugin which fails to build keeps its previous executable, if exists.
+func rebuildDevPluginIfNeeded(devDir string, devPlugin *DevPlugin) {
+	exePath := getDevPluginExecutablePath(devDir, devPlugin.Name)
+	var builtTime time.Time
+	if fileInfo, err := os.Stat(exePath); err == nil {
+		builtTime = fileInfo.ModTime()
+	}
+	modified, err := isModifiedSince(devPlugin.SourcePath, builtTime)
+	if err != nil {
+		log.Warn(pluginsErrorPrefix + "failed checking the sources of development plugin '" + devPlugin.Name + "': " + err.Error())
+		return
+	}
+	if !modified {
+		return
+	}
+	if err = buildDevPlugin(devPlugin, exePath); err != nil {
+		log.Error(pluginsErrorPrefix + err.Error())
+	}
+}
+
+var errSourcesModified = errors.New("sources modified")
+
+// Checks if any of the Go sources in the provided dir was modified after the provided time.
+// Hidden dirs, such as .git, are skipped.
+func isModifiedSince(sourcePath string, since time.Time) (bool, error) {
+	err := filepath.Walk(sourcePath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if filePath != sourcePath && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if (strings.HasSuffix(info.Name(), ".go") || info.Name() == "go.mod" || info.Name() == "go.sum") && info.ModTime().After(since) {
+			return errSourcesModified
+		}
+		return nil
+	})
+	if err == errSourcesModified {
+		return true, nil
+	}
+	return false, errorutils.CheckError(err)
+}
+
+func buildDevPlugin(devPlugin *DevPlugin, exePath string) error {
+	log.Info("Building development plugin '" + devPlugin.Name + "' from " + devPlugin.SourcePath + "...")
+	cmd := exec.Command("go", "build", "-o", exePath)
+	cmd.Dir = devPlugin.SourcePath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return errorutils.CheckError(errors.New("failed building development plugin '" + devPlugin.Name + "': " + err.Error() + "\n" + string(output)))
+	}
+	return nil
+}
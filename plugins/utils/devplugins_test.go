@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	coreTests "github.com/jfrog/jfrog-cli-core/utils/tests"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetModulePluginName(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+
+	// Missing go.mod.
+	_, err = getModulePluginName(tmpDir)
+	assert.Error(t, err)
+
+	tests := []struct {
+		goMod        string
+		expectedName string
+	}{
+		{"module github.com/jfrog/hello-frog\n\ngo 1.14\n", "hello-frog"},
+		{"// A comment.\nmodule \"github.com/jfrog/hello-frog/v2\"\n", "hello-frog"},
+		{"module hello-frog\n", "hello-frog"},
+	}
+	for _, test := range tests {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(test.goMod), 0600))
+		name, err := getModulePluginName(tmpDir)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expectedName, name)
+	}
+}
+
+func TestIsModifiedSince(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	sourcePath := filepath.Join(tmpDir, "main.go")
+	assert.NoError(t, ioutil.WriteFile(sourcePath, []byte("package main"), 0600))
+	hiddenDir := filepath.Join(tmpDir, ".git")
+	assert.NoError(t, os.Mkdir(hiddenDir, 0700))
+	hiddenPath := filepath.Join(hiddenDir, "hidden.go")
+	assert.NoError(t, ioutil.WriteFile(hiddenPath, []byte("package hidden"), 0600))
+
+	builtTime := time.Now()
+	past := builtTime.Add(-time.Hour)
+	future := builtTime.Add(time.Hour)
+	assert.NoError(t, os.Chtimes(sourcePath, past, past))
+	// Hidden dirs should be ignored.
+	assert.NoError(t, os.Chtimes(hiddenPath, future, future))
+	modified, err := isModifiedSince(tmpDir, builtTime)
+	assert.NoError(t, err)
+	assert.False(t, modified)
+
+	// A never built plugin should be considered modified.
+	modified, err = isModifiedSince(tmpDir, time.Time{})
+	assert.NoError(t, err)
+	assert.True(t, modified)
+
+	assert.NoError(t, os.Chtimes(sourcePath, future, future))
+	modified, err = isModifiedSince(tmpDir, builtTime)
+	assert.NoError(t, err)
+	assert.True(t, modified)
+}
+
+func TestDevPluginsLinks(t *testing.T) {
+	devDir, err := fileutils.CreateTempDir()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer fileutils.RemoveTempDir(devDir)
+
+	devPlugins, err := readDevPluginsLinks(devDir)
+	assert.NoError(t, err)
+	assert.Empty(t, devPlugins)
+
+	expected := []*DevPlugin{{Name: "hello-frog", SourcePath: filepath.Join("src", "hello-frog")}}
+	assert.NoError(t, writeDevPluginsLinks(devDir, expected))
+	devPlugins, err = readDevPluginsLinks(devDir)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, devPlugins)
+}
+
+func TestDevPluginsRebuiltLazily(t *testing.T) {
+	// Clean from previous tests.
+	coreTests.CleanUnitTestsJfrogHome()
+	// Create temp jfrog home
+	oldHome, err := coreTests.SetJfrogHome()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+	defer coreTests.CleanUnitTestsJfrogHome()
+
+	sourcePath, err := fileutils.CreateTempDir()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer fileutils.RemoveTempDir(sourcePath)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(sourcePath, "go.mod"), []byte("module github.com/jfrog/hello-frog\n"), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(sourcePath, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0600))
+	devDir, err := GetDevPluginsDir()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, os.MkdirAll(devDir, 0777))
+	assert.NoError(t, writeDevPluginsLinks(devDir, []*DevPlugin{{Name: "hello-frog", SourcePath: sourcePath}}))
+	exePath := getDevPluginExecutablePath(devDir, "hello-frog")
+
+	// Loading the signatures should not build the plugin.
+	_, err = getDevPluginsSignatures()
+	assert.NoError(t, err)
+	exists, err := fileutils.IsFileExists(exePath, false)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// Executing the plugin should build it first.
+	rebuildDevPluginByExecutableIfNeeded(exePath)
+	exists, err = fileutils.IsFileExists(exePath, false)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
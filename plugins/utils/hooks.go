@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/redact"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The hidden command executed on a plugin when a command it registered a hook to is run.
+// The hook's payload is written to the plugin's standard input.
+const HookCommandName = "hidden-plugin-hook"
+
+const (
+	beforeHookPhase = "before"
+	afterHookPhase  = "after"
+)
+
+// A hook registered by a plugin, to run before or after a built-in command.
+// Hooks are declared in the plugin's signature, such as: 'before:rt upload' or 'after:rt build-publish'.
+type pluginHook struct {
+	phase       string
+	commandPath []string
+	execPath    string
+}
+
+// The payload passed to a plugin when its hook is run.
+type HookPayload struct {
+	Hook    string   `json:"hook"`
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+	// The options the command was run with, by their names.
+	Flags map[string]string `json:"flags,omitempty"`
+	// The command's result, such as its summary. Only provided to 'after' hooks.
+	Result interface{} `json:"result,omitempty"`
+	// The command's error. Only provided to 'after' hooks.
+	Error string `json:"error,omitempty"`
+}
+
+func parseHook(hook, execPath string) (*pluginHook, error) {
+	split := strings.SplitN(hook, ":", 2)
+	if len(split) != 2 || (split[0] != beforeHookPhase && split[0] != afterHookPhase) || len(strings.Fields(split[1])) == 0 {
+		return nil, errorutils.CheckError(errors.New("invalid hook '" + hook + "'. A hook should be formatted as 'before:<command>' or 'after:<command>'"))
+	}
+	return &pluginHook{phase: split[0], commandPath: strings.Fields(split[1]), execPath: execPath}, nil
+}
+
+// Wraps the actions of the built-in commands which the plugins registered hooks to.
+// Invalid hooks, and hooks registered to unknown commands, are skipped with a warning.
+func registerHooks(signatures []*pluginSignature, builtInCommands []cli.Command) {
+	hooksPerCommand := map[*cli.Command][]*pluginHook{}
+	commandsPaths := map[*cli.Command]string{}
+	for _, sig := range signatures {
+		pluginName := getPluginNameFromPath(sig.ExecutablePath)
+		for _, hookStr := range sig.Hooks {
+			hook, err := parseHook(hookStr, sig.ExecutablePath)
+			if err != nil {
+				log.Warn(pluginsErrorPrefix + "plugin '" + pluginName + "' declares an " + err.Error())
+				continue
+			}
+			cmd, commandPath := findCommand(builtInCommands, hook.commandPath)
+			if cmd == nil {
+				log.Warn(pluginsErrorPrefix + "plugin '" + pluginName + "' declares a hook to an unknown command: '" + hookStr + "'. Skipping...")
+				continue
+			}
+			hooksPerCommand[cmd] = append(hooksPerCommand[cmd], hook)
+			commandsPaths[cmd] = commandPath
+		}
+	}
+	for cmd, hooks := range hooksPerCommand {
+		cmd.Action = wrapActionWithHooks(cmd.Action, commandsPaths[cmd], hooks)
+	}
+}
+
+// Returns the command matching the provided path of names or aliases, along with the command's full name.
+// Returns nil if no command matches the path, or if the path leads to a namespace of commands.
+func findCommand(commands []cli.Command, path []string) (*cli.Command, string) {
+	for i := range commands {
+		if !commands[i].HasName(path[0]) {
+			continue
+		}
+		if len(path) == 1 {
+			if len(commands[i].Subcommands) > 0 {
+				return nil, ""
+			}
+			return &commands[i], commands[i].Name
+		}
+		cmd, subcommandPath := findCommand(commands[i].Subcommands, path[1:])
+		if cmd == nil {
+			return nil, ""
+		}
+		return cmd, commands[i].Name + " " + subcommandPath
+	}
+	return nil, ""
+}
+
+// Runs the 'before' hooks, the command's action and then the 'after' hooks.
+// A failing 'before' hook aborts the command. A failing 'after' hook is logged, without failing the command.
+func wrapActionWithHooks(action interface{}, commandPath string, hooks []*pluginHook) func(*cli.Context) error {
+	return func(c *cli.Context) error {
+		args, flags := getCommandArgsAndFlags(c)
+		for _, hook := range hooks {
+			if hook.phase != beforeHookPhase {
+				continue
+			}
+			if err := hook.run(&HookPayload{Hook: beforeHookPhase + ":" + commandPath, Command: commandPath, Args: args, Flags: flags}); err != nil {
+				return errorutils.CheckError(fmt.Errorf("the '%s' command was aborted by the hook of plugin '%s': %s",
+					commandPath, getPluginNameFromPath(hook.execPath), err.Error()))
+			}
+		}
+		err := cli.HandleAction(action, c)
+		payload := &HookPayload{Hook: afterHookPhase + ":" + commandPath, Command: commandPath, Args: args, Flags: flags, Result: cliutils.GetCommandReport(c.App).Result}
+		if err != nil {
+			payload.Error = err.Error()
+		}
+		for _, hook := range hooks {
+			if hook.phase != afterHookPhase {
+				continue
+			}
+			if hookErr := hook.run(payload); hookErr != nil {
+				log.Warn(pluginsErrorPrefix + "the hook of plugin '" + getPluginNameFromPath(hook.execPath) + "' failed: " + hookErr.Error())
+			}
+		}
+		return err
+	}
+}
+
+// Returns the arguments and the options the command was run with, as parsed into its context.
+// Global options, which precede the command, are not included.
+// The values of the options which hold credentials, such as --password and --access-token, and the secrets in the arguments are masked,
+// since the payload is passed to third-party plugins.
+func getCommandArgsAndFlags(c *cli.Context) ([]string, map[string]string) {
+	args := redact.Args(c.Args())
+	flags := map[string]string{}
+	for _, name := range c.FlagNames() {
+		if !c.IsSet(name) {
+			continue
+		}
+		if redact.IsSecretOption(name) {
+			flags[name] = redact.Mask
+		} else {
+			flags[name] = redact.String(c.String(name))
+		}
+	}
+	return args, flags
+}
+
+// Runs the plugin's hook command, with the payload written to its standard input.
+// The plugin's output is written to the standard error, to keep the output of the hooked command intact.
+func (hook *pluginHook) run(payload *HookPayload) error {
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Debug("Running plugin hook '" + payload.Hook + "' of plugin '" + getPluginNameFromPath(hook.execPath) + "'...")
+	cmd := exec.Command(hook.execPath, HookCommandName)
+	cmd.Stdin = bytes.NewReader(content)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils/version"
+	"gopkg.in/yaml.v2"
+)
+
+// The plugins lock file is located in the project's .jfrog directory.
+const PluginsLockFileName = "plugins.yaml"
+
+// Pins the plugins required by a project.
+type PluginsLock struct {
+	Plugins []*LockedPlugin `yaml:"plugins"`
+}
+
+// A single pinned plugin.
+// The expected sha256 checksums of the plugin's executable are mapped by architecture (for example: linux-amd64).
+type LockedPlugin struct {
+	Name    string            `yaml:"name"`
+	Version string            `yaml:"version"`
+	Sha256  map[string]string `yaml:"sha256,omitempty"`
+}
+
+// Returns the expected sha256 checksum of the plugin's executable for the provided architecture, or an empty string if not pinned.
+func (plugin *LockedPlugin) GetSha256(arc string) string {
+	return plugin.Sha256[arc]
+}
+
+func (plugin *LockedPlugin) SetSha256(arc, sha256 string) {
+	if plugin.Sha256 == nil {
+		plugin.Sha256 = map[string]string{}
+	}
+	plugin.Sha256[arc] = sha256
+}
+
+// Compares two plugin versions, ignoring the optional 'v' prefix.
+// Returns 1 if ver1 is newer than ver2, -1 if it is older and 0 if they are equal.
+func CompareVersions(ver1, ver2 string) int {
+	return version.NewVersion(strings.TrimPrefix(ver2, "v")).Compare(strings.TrimPrefix(ver1, "v"))
+}
+
+// Searches for the plugins lock file in the .jfrog directory of the current directory or one of its parents.
+// Returns an empty path if not found.
+func GetPluginsLockPath() (string, error) {
+	projectDir, exists, err := fileutils.FindUpstream(".jfrog", fileutils.Dir)
+	if err != nil || !exists {
+		return "", err
+	}
+	lockPath := filepath.Join(projectDir, ".jfrog", PluginsLockFileName)
+	exists, err = fileutils.IsFileExists(lockPath, false)
+	if err != nil || !exists {
+		return "", err
+	}
+	return lockPath, nil
+}
+
+func ReadPluginsLock(lockPath string) (*PluginsLock, error) {
+	content, err := ioutil.ReadFile(lockPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	lock := new(PluginsLock)
+	if err = yaml.Unmarshal(content, lock); err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("failed parsing the plugins lock file %s: %s", lockPath, err.Error()))
+	}
+	return lock, lock.validate(lockPath)
+}
+
+func (lock *PluginsLock) validate(lockPath string) error {
+	names := map[string]bool{}
+	for _, plugin := range lock.Plugins {
+		if plugin.Name == "" {
+			return errorutils.CheckError(errors.New("a plugin without a name is pinned in " + lockPath))
+		}
+		if plugin.Version == "" || plugin.Version == commandsUtils.LatestVersionName {
+			return errorutils.CheckError(errors.New("plugin '" + plugin.Name + "' must be pinned to a specific version in " + lockPath))
+		}
+		if names[plugin.Name] {
+			return errorutils.CheckError(errors.New("plugin '" + plugin.Name + "' is pinned more than once in " + lockPath))
+		}
+		names[plugin.Name] = true
+	}
+	return nil
+}
+
+func WritePluginsLock(lockPath string, lock *PluginsLock) error {
+	content, err := yaml.Marshal(lock)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(lockPath, content, 0644))
+}
+
+// Logs a warning for every plugin pinned in the project's plugins lock file, which is missing or differs from the installed plugin.
+// Installed checksums and versions are taken from the signatures cache when available, to avoid reading or running the executables on each CLI invocation.
+func warnOnPluginsLockDrift(pluginsDir string) {
+	lockPath, err := GetPluginsLockPath()
+	if err != nil || lockPath == "" {
+		return
+	}
+	lock, err := ReadPluginsLock(lockPath)
+	if err != nil {
+		log.Warn(pluginsErrorPrefix + err.Error())
+		return
+	}
+	arc, err := commandsUtils.GetLocalArchitecture()
+	if err != nil {
+		return
+	}
+	cache := readSignaturesCache(pluginsDir)
+	for _, pinned := range lock.Plugins {
+		drift, err := getPluginLockDrift(pluginsDir, pinned, arc, cache)
+		if err != nil {
+			log.Debug(pluginsErrorPrefix + "failed checking plugin '" + pinned.Name + "' against the plugins lock file: " + err.Error())
+			continue
+		}
+		if drift != "" {
+			log.Warn(fmt.Sprintf("%splugin '%s' %s the version pinned in %s (%s). Run 'jfrog plugin sync' to install the pinned version.",
+				pluginsErrorPrefix, pinned.Name, drift, lockPath, pinned.Version))
+		}
+	}
+	if err = cache.save(pluginsDir); err != nil {
+		log.Debug(pluginsErrorPrefix + "failed saving the signatures cache: " + err.Error())
+	}
+}
+
+// Returns a description of how the installed plugin drifts from the lock, or an empty string if it does not.
+// If no checksum is pinned for the local architecture, the version of the installed plugin is compared to the pinned version.
+func getPluginLockDrift(pluginsDir string, pinned *LockedPlugin, arc string, cache *signaturesCache) (string, error) {
+	exeName := commandsUtils.GetLocalPluginExecutableName(pinned.Name)
+	exePath := filepath.Join(pluginsDir, exeName)
+	fileInfo, err := os.Stat(exePath)
+	if os.IsNotExist(err) {
+		return "is not installed, although required by", nil
+	}
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	cached, ok := cache.Plugins[exeName]
+	if ok && (cached.Size != fileInfo.Size() || cached.ModTime != fileInfo.ModTime().UnixNano()) {
+		cached = nil
+	}
+	expectedSha256 := pinned.GetSha256(arc)
+	if expectedSha256 == "" {
+		actualVersion, err := getInstalledPluginVersion(exePath, cached, cache)
+		if err != nil {
+			return "", err
+		}
+		if CompareVersions(actualVersion, pinned.Version) != 0 {
+			return "(version " + actualVersion + ") differs from", nil
+		}
+		return "", nil
+	}
+	actualSha256 := ""
+	if cached != nil {
+		actualSha256 = cached.Sha256
+	} else if actualSha256, err = CalcSha256(exePath); err != nil {
+		return "", err
+	}
+	if actualSha256 != expectedSha256 {
+		return "differs from", nil
+	}
+	return "", nil
+}
+
+// Returns the version of the installed plugin from its cached signature, if available.
+// Otherwise, the plugin's version command is run, and the version is cached with the plugin's signature.
+func getInstalledPluginVersion(exePath string, cached *cachedSignature, cache *signaturesCache) (string, error) {
+	if cached != nil && cached.Version != "" {
+		return cached.Version, nil
+	}
+	output, err := gofrogcmd.RunCmdOutput(&PluginExecCmd{exePath, []string{commandsUtils.PluginVersionCommandName}})
+	if err != nil {
+		return "", err
+	}
+	pluginVersion, err := commandsUtils.ParsePluginVersion(output)
+	if err != nil {
+		return "", err
+	}
+	if cached != nil {
+		cached.Version = pluginVersion
+		cache.modified = true
+	}
+	return pluginVersion, nil
+}
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPluginsLock(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	lockPath := filepath.Join(tmpDir, PluginsLockFileName)
+
+	tests := []struct {
+		name    string
+		content string
+		valid   bool
+	}{
+		{"valid", "plugins:\n- name: hello-frog\n  version: v1.0.0\n  sha256:\n    linux-amd64: abc\n", true},
+		{"noChecksums", "plugins:\n- name: hello-frog\n  version: v1.0.0\n", true},
+		{"noVersion", "plugins:\n- name: hello-frog\n", false},
+		{"latestVersion", "plugins:\n- name: hello-frog\n  version: latest\n", false},
+		{"duplicate", "plugins:\n- name: hello-frog\n  version: v1.0.0\n- name: hello-frog\n  version: v1.0.1\n", false},
+		{"corrupted", "plugins: [", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.NoError(t, ioutil.WriteFile(lockPath, []byte(test.content), 0644))
+			_, err := ReadPluginsLock(lockPath)
+			assert.Equal(t, test.valid, err == nil)
+		})
+	}
+
+	// Write and read back a lock.
+	lock := &PluginsLock{Plugins: []*LockedPlugin{{Name: "hello-frog", Version: "v1.0.0"}}}
+	lock.Plugins[0].SetSha256("linux-amd64", "abc")
+	assert.NoError(t, WritePluginsLock(lockPath, lock))
+	readLock, err := ReadPluginsLock(lockPath)
+	if assert.NoError(t, err) {
+		assert.Equal(t, lock, readLock)
+	}
+}
+
+func TestGetPluginLockDrift(t *testing.T) {
+	pluginsDir, err := fileutils.CreateTempDir()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer fileutils.RemoveTempDir(pluginsDir)
+	cache := &signaturesCache{Plugins: map[string]*cachedSignature{}}
+	pinned := &LockedPlugin{Name: "plugin-mock", Version: "v1.0.0"}
+
+	// Missing plugin.
+	drift, err := getPluginLockDrift(pluginsDir, pinned, "linux-amd64", cache)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, drift)
+
+	execPath := filepath.Join(pluginsDir, commandsUtils.GetLocalPluginExecutableName("plugin-mock"))
+	assert.NoError(t, ioutil.WriteFile(execPath, []byte("plugin-mock"), 0700))
+	sha256, err := CalcSha256(execPath)
+	assert.NoError(t, err)
+	fileInfo, err := os.Stat(execPath)
+	assert.NoError(t, err)
+	assert.NoError(t, cache.set(fileInfo, execPath, nil))
+
+	// No checksum pinned - the version, taken from the signatures cache, is compared.
+	cache.Plugins[fileInfo.Name()].Version = "v1.0.0"
+	drift, err = getPluginLockDrift(pluginsDir, pinned, "linux-amd64", cache)
+	assert.NoError(t, err)
+	assert.Empty(t, drift)
+	cache.Plugins[fileInfo.Name()].Version = "v1.1.0"
+	drift, err = getPluginLockDrift(pluginsDir, pinned, "linux-amd64", cache)
+	assert.NoError(t, err)
+	assert.Contains(t, drift, "v1.1.0")
+
+	// Matching and differing checksums.
+	pinned.SetSha256("linux-amd64", sha256)
+	drift, err = getPluginLockDrift(pluginsDir, pinned, "linux-amd64", cache)
+	assert.NoError(t, err)
+	assert.Empty(t, drift)
+	pinned.SetSha256("linux-amd64", "abc")
+	drift, err = getPluginLockDrift(pluginsDir, pinned, "linux-amd64", cache)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, drift)
+
+	// The checksum should be taken from the signatures cache, if the executable was not changed since cached.
+	cache.Plugins[fileInfo.Name()].Sha256 = "abc"
+	drift, err = getPluginLockDrift(pluginsDir, pinned, "linux-amd64", cache)
+	assert.NoError(t, err)
+	assert.Empty(t, drift)
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		ver1     string
+		ver2     string
+		expected int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"v1.0.1", "v1.0.0", 1},
+		{"v1.2.0", "1.10.0", -1},
+		{"v2.0.0", "v1.10.3", 1},
+	}
+	for _, test := range tests {
+		t.Run(test.ver1+"-"+test.ver2, func(t *testing.T) {
+			assert.Equal(t, test.expected, CompareVersions(test.ver1, test.ver2))
+		})
+	}
+}
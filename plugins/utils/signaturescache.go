@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The signatures cache file is stored in the plugins directory, and is skipped when looping over the plugins.
+const SignaturesCacheFileName = ".signatures-cache.json"
+
+// Holds the signatures of the installed plugins, to avoid executing every plugin on each CLI invocation.
+// The cache is keyed by the plugin's executable file name.
+type signaturesCache struct {
+	Plugins map[string]*cachedSignature `json:"plugins"`
+	// Set when the cache content was changed and should be saved.
+	modified bool
+}
+
+// A signature of a single plugin, along with the details of the executable it was retrieved from.
+type cachedSignature struct {
+	Size      int64            `json:"size"`
+	ModTime   int64            `json:"modTime"`
+	Sha256    string           `json:"sha256"`
+	Signature *pluginSignature `json:"signature"`
+	// The version of the plugin, set only once it's needed to compare the plugin to the plugins lock file.
+	Version string `json:"version,omitempty"`
+}
+
+func getSignaturesCachePath(pluginsDir string) string {
+	return filepath.Join(pluginsDir, SignaturesCacheFileName)
+}
+
+// Reads the signatures cache from the plugins directory.
+// A missing or corrupted cache file results in an empty cache, which will be rebuilt lazily.
+func readSignaturesCache(pluginsDir string) *signaturesCache {
+	cache := &signaturesCache{Plugins: map[string]*cachedSignature{}}
+	content, err := ioutil.ReadFile(getSignaturesCachePath(pluginsDir))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Debug(pluginsErrorPrefix + "failed reading the signatures cache: " + err.Error())
+		}
+		return cache
+	}
+	if err = json.Unmarshal(content, cache); err != nil || cache.Plugins == nil {
+		log.Debug(pluginsErrorPrefix + "the signatures cache is corrupted and will be rebuilt.")
+		cache.Plugins = map[string]*cachedSignature{}
+		cache.modified = true
+	}
+	return cache
+}
+
+// Writes the signatures cache to the plugins directory, if it was modified.
+func (cache *signaturesCache) save(pluginsDir string) error {
+	if !cache.modified {
+		return nil
+	}
+	content, err := json.Marshal(cache)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(getSignaturesCachePath(pluginsDir), content, 0600))
+}
+
+// Returns the cached signature of the provided plugin executable, or nil if the executable was changed since it was cached.
+// If only the modification time of the executable was changed, the checksum is compared to avoid executing an unchanged plugin.
+func (cache *signaturesCache) get(fileInfo os.FileInfo, execPath string) (*pluginSignature, error) {
+	cached, ok := cache.Plugins[fileInfo.Name()]
+	if !ok || cached.Signature == nil || cached.Size != fileInfo.Size() {
+		return nil, nil
+	}
+	if cached.ModTime == fileInfo.ModTime().UnixNano() {
+		return cached.Signature, nil
+	}
+	sha256, err := CalcSha256(execPath)
+	if err != nil || sha256 != cached.Sha256 {
+		return nil, err
+	}
+	cached.ModTime = fileInfo.ModTime().UnixNano()
+	cache.modified = true
+	return cached.Signature, nil
+}
+
+// Stores the signature of the provided plugin executable in the cache.
+func (cache *signaturesCache) set(fileInfo os.FileInfo, execPath string, signature *pluginSignature) error {
+	sha256, err := CalcSha256(execPath)
+	if err != nil {
+		return err
+	}
+	cache.Plugins[fileInfo.Name()] = &cachedSignature{
+		Size:      fileInfo.Size(),
+		ModTime:   fileInfo.ModTime().UnixNano(),
+		Sha256:    sha256,
+		Signature: signature,
+	}
+	cache.modified = true
+	return nil
+}
+
+// Removes the entries of plugins that no longer exist in the plugins directory.
+func (cache *signaturesCache) removeMissing(existing map[string]bool) {
+	for name := range cache.Plugins {
+		if !existing[name] {
+			delete(cache.Plugins, name)
+			cache.modified = true
+		}
+	}
+}
+
+// Deletes the signatures cache, so that it is rebuilt on the next CLI invocation.
+// Should be called whenever a plugin is installed or uninstalled.
+func InvalidateSignaturesCache() error {
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	if err != nil {
+		return err
+	}
+	cachePath := getSignaturesCachePath(pluginsDir)
+	exists, err := fileutils.IsFileExists(cachePath, false)
+	if err != nil || !exists {
+		return err
+	}
+	log.Debug("Invalidating the plugins signatures cache...")
+	return errorutils.CheckError(os.Remove(cachePath))
+}
+
+// Returns the sha256 checksum of the provided file.
+func CalcSha256(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
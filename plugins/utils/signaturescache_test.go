@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const pluginMockPath = "../../testdata/plugins/plugin-mock"
+
+func TestSignaturesCache(t *testing.T) {
+	pluginsDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(pluginsDir)
+	if err = fileutils.CopyFile(pluginsDir, pluginMockPath); err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	execPath := filepath.Join(pluginsDir, filepath.Base(pluginMockPath))
+	signature := &pluginSignature{PluginSignature: components.PluginSignature{Name: "plugin-mock", Usage: "A plugin mock."}}
+
+	// Cache the signature and read it back from the cache file.
+	cache := readSignaturesCache(pluginsDir)
+	assert.Empty(t, cache.Plugins)
+	fileInfo := getFileInfo(t, execPath)
+	assert.NoError(t, cache.set(fileInfo, execPath, signature))
+	assert.NoError(t, cache.save(pluginsDir))
+	cache = readSignaturesCache(pluginsDir)
+	cached, err := cache.get(fileInfo, execPath)
+	assert.NoError(t, err)
+	assert.Equal(t, signature, cached)
+
+	// Touching the executable without changing its content should not invalidate the cached signature.
+	newTime := time.Now().Add(time.Hour)
+	assert.NoError(t, os.Chtimes(execPath, newTime, newTime))
+	cached, err = cache.get(getFileInfo(t, execPath), execPath)
+	assert.NoError(t, err)
+	assert.Equal(t, signature, cached)
+	assert.True(t, cache.modified)
+
+	// Changing the executable's content should invalidate the cached signature.
+	assert.NoError(t, ioutil.WriteFile(execPath, []byte("This is a modified plugin mock."), 0700))
+	cached, err = cache.get(getFileInfo(t, execPath), execPath)
+	assert.NoError(t, err)
+	assert.Nil(t, cached)
+
+	// Entries of removed plugins should be removed from the cache.
+	cache.removeMissing(map[string]bool{})
+	assert.Empty(t, cache.Plugins)
+}
+
+func TestReadCorruptedSignaturesCache(t *testing.T) {
+	pluginsDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(pluginsDir)
+	assert.NoError(t, ioutil.WriteFile(getSignaturesCachePath(pluginsDir), []byte("not a json"), 0600))
+	cache := readSignaturesCache(pluginsDir)
+	assert.Empty(t, cache.Plugins)
+	assert.True(t, cache.modified)
+}
+
+func getFileInfo(t *testing.T, path string) os.FileInfo {
+	fileInfo, err := os.Stat(path)
+	assert.NoError(t, err)
+	return fileInfo
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/codegangsta/cli"
 	gofrogcmd "github.com/jfrog/gofrog/io"
@@ -21,13 +22,70 @@ import (
 
 const pluginsErrorPrefix = "jfrog cli plugins: "
 
-// Gets all the installed plugins' signatures by looping over the plugins dir.
-func getPluginsSignatures() ([]*components.PluginSignature, error) {
-	var signatures []*components.PluginSignature
+// Marks the usage of development plugins in the commands list.
+const devPluginUsagePrefix = "[dev plugin] "
+
+// The signature returned by a plugin.
+// Extends the signature defined in jfrog-cli-core with the hooks the plugin registers to the CLI's commands.
+type pluginSignature struct {
+	components.PluginSignature
+	Hooks []string `json:"hooks,omitempty"`
+	// Set for development plugins, linked to their local sources.
+	dev bool
+}
+
+// Gets the signatures of all the installed plugins and the linked development plugins.
+// A development plugin shadows an installed plugin with the same name.
+func getPluginsSignatures() ([]*pluginSignature, error) {
 	pluginsDir, err := coreutils.GetJfrogPluginsDir()
 	if err != nil {
+		return nil, err
+	}
+	signatures, err := getSignaturesFromDir(pluginsDir)
+	devSignatures, devErr := getDevPluginsSignatures()
+	if err == nil {
+		err = devErr
+	}
+	if len(devSignatures) == 0 {
 		return signatures, err
 	}
+	devPluginsNames := map[string]bool{}
+	for _, sig := range devSignatures {
+		devPluginsNames[getPluginNameFromPath(sig.ExecutablePath)] = true
+	}
+	var merged []*pluginSignature
+	for _, sig := range signatures {
+		pluginName := getPluginNameFromPath(sig.ExecutablePath)
+		if devPluginsNames[pluginName] {
+			log.Debug(pluginsErrorPrefix + "the installed plugin '" + pluginName + "' is shadowed by the linked development plugin.")
+			continue
+		}
+		merged = append(merged, sig)
+	}
+	return append(merged, devSignatures...), err
+}
+
+// Gets the signatures of the linked development plugins, from their last built executables.
+func getDevPluginsSignatures() ([]*pluginSignature, error) {
+	devDir, err := GetDevPluginsDir()
+	if err != nil {
+		return nil, err
+	}
+	devPlugins, err := readDevPluginsLinks(devDir)
+	if err != nil || len(devPlugins) == 0 {
+		return nil, err
+	}
+	signatures, err := getSignaturesFromDir(devDir)
+	for _, sig := range signatures {
+		sig.dev = true
+	}
+	return signatures, err
+}
+
+// Gets the signatures of the plugins in the provided dir, by looping over the dir.
+// Signatures are loaded from the signatures cache, and plugins are executed only if they were changed since cached.
+func getSignaturesFromDir(pluginsDir string) ([]*pluginSignature, error) {
+	var signatures []*pluginSignature
 	exists, err := fileutils.IsDirExists(pluginsDir, false)
 	if err != nil || !exists {
 		return signatures, err
@@ -38,37 +96,64 @@ func getPluginsSignatures() ([]*components.PluginSignature, error) {
 		return signatures, errorutils.CheckError(err)
 	}
 
+	cache := readSignaturesCache(pluginsDir)
+	existing := map[string]bool{}
 	var finalErr error
 	for _, f := range files {
+		if f.Name() == SignaturesCacheFileName || f.Name() == devPluginsLinksFileName {
+			continue
+		}
 		if f.IsDir() {
 			logSkippablePluginsError("unexpected directory in plugins directory", f.Name(), nil)
 			continue
 		}
-		pluginName := strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))
+		existing[f.Name()] = true
 		execPath := filepath.Join(pluginsDir, f.Name())
-		output, err := gofrogcmd.RunCmdOutput(
-			&PluginExecCmd{
-				execPath,
-				[]string{plugins.SignatureCommandName},
-			})
+		pluginName := getPluginNameFromPath(execPath)
+		curSignature, err := cache.get(f, execPath)
 		if err != nil {
-			finalErr = err
-			logSkippablePluginsError("failed getting signature from plugin", pluginName, err)
-			continue
+			log.Debug(pluginsErrorPrefix + "failed reading the cached signature of plugin '" + pluginName + "': " + err.Error())
 		}
-		curSignature := new(components.PluginSignature)
-		err = json.Unmarshal([]byte(output), &curSignature)
-		if err != nil {
-			finalErr = err
-			logSkippablePluginsError("failed unmarshalling signature from plugin", pluginName, err)
-			continue
+		if curSignature == nil {
+			curSignature, err = getSignatureFromPlugin(execPath, pluginName)
+			if err != nil {
+				finalErr = err
+				continue
+			}
+			if err = cache.set(f, execPath, curSignature); err != nil {
+				log.Debug(pluginsErrorPrefix + "failed caching the signature of plugin '" + pluginName + "': " + err.Error())
+			}
 		}
 		curSignature.ExecutablePath = execPath
 		signatures = append(signatures, curSignature)
 	}
+	cache.removeMissing(existing)
+	if err = cache.save(pluginsDir); err != nil {
+		log.Debug(pluginsErrorPrefix + "failed saving the signatures cache: " + err.Error())
+	}
 	return signatures, finalErr
 }
 
+// Executes the plugin with the signature command, and parses the returned signature.
+func getSignatureFromPlugin(execPath, pluginName string) (*pluginSignature, error) {
+	output, err := gofrogcmd.RunCmdOutput(
+		&PluginExecCmd{
+			execPath,
+			[]string{plugins.SignatureCommandName},
+		})
+	if err != nil {
+		logSkippablePluginsError("failed getting signature from plugin", pluginName, err)
+		return nil, err
+	}
+	signature := new(pluginSignature)
+	err = json.Unmarshal([]byte(output), &signature)
+	if err != nil {
+		logSkippablePluginsError("failed unmarshalling signature from plugin", pluginName, err)
+		return nil, err
+	}
+	return signature, nil
+}
+
 func logSkippablePluginsError(msg, pluginName string, err error) {
 	log.Error(fmt.Sprintf("%s%s: '%s'. Skiping...", pluginsErrorPrefix, msg, pluginName))
 	if err != nil {
@@ -77,14 +162,18 @@ func logSkippablePluginsError(msg, pluginName string, err error) {
 }
 
 // Converts signatures to commands to be appended to the CLI commands.
-func signaturesToCommands(signatures []*components.PluginSignature) []cli.Command {
+func signaturesToCommands(signatures []*pluginSignature) []cli.Command {
 	var commands []cli.Command
 	for _, sig := range signatures {
+		usage := sig.Usage
+		if sig.dev {
+			usage = devPluginUsagePrefix + usage
+		}
 		commands = append(commands, cli.Command{
 			Name:            sig.Name,
-			Usage:           sig.Usage,
+			Usage:           usage,
 			SkipFlagParsing: true,
-			Action:          getAction(*sig),
+			Action:          getAction(sig.PluginSignature),
 		})
 	}
 	return commands
@@ -92,20 +181,110 @@ func signaturesToCommands(signatures []*components.PluginSignature) []cli.Comman
 
 func getAction(sig components.PluginSignature) func(*cli.Context) error {
 	return func(c *cli.Context) error {
-		cmd := exec.Command(sig.ExecutablePath, cliutils.ExtractCommand(c)...)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		cmd.Stdin = os.Stdin
-		return cmd.Run()
+		return ExecPlugin(sig.ExecutablePath, cliutils.ExtractCommand(c))
 	}
 }
 
-func GetPlugins() []cli.Command {
+// Runs the plugin's executable with the provided arguments, attached to the CLI's standard streams.
+// A development plugin is rebuilt first, if its sources were modified since it was built.
+func ExecPlugin(execPath string, args []string) error {
+	rebuildDevPluginByExecutableIfNeeded(execPath)
+	cmd := exec.Command(execPath, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
+
+// Returns the plugins as commands, to be appended to the provided built-in commands.
+// Plugins which their names collide with a built-in command, or with another plugin, are not registered.
+// Such plugins can still be run using the 'jfrog plugin run' command.
+// The hooks declared by the registered plugins are registered to the built-in commands.
+// A warning is also logged for installed plugins which drift from the project's plugins lock file.
+func GetPlugins(builtInCommands []cli.Command) []cli.Command {
 	signatures, err := getPluginsSignatures()
 	if err != nil {
 		// Intentionally ignoring error to avoid failing if running other commands.
 		log.Error("failed adding certain plugins as commands. Last error: " + err.Error())
 		return []cli.Command{}
 	}
+	if pluginsDir, err := coreutils.GetJfrogPluginsDir(); err == nil {
+		warnOnPluginsLockDrift(pluginsDir)
+	}
+	return registerPlugins(signatures, builtInCommands)
+}
+
+// Returns the plugins which do not collide with the built-in commands or with each other as commands, and registers their hooks.
+// The colliding plugins are not registered, so their hooks are not registered either.
+func registerPlugins(signatures []*pluginSignature, builtInCommands []cli.Command) []cli.Command {
+	signatures = removeCollidingSignatures(signatures, builtInCommands)
+	registerHooks(signatures, builtInCommands)
 	return signaturesToCommands(signatures)
 }
+
+// Returns the signatures which their names do not collide with the names or aliases of the built-in commands, or with other plugins.
+// A warning is logged for every plugin removed.
+func removeCollidingSignatures(signatures []*pluginSignature, builtInCommands []cli.Command) []*pluginSignature {
+	// The help command is added to the CLI by codegangsta.
+	builtInNames := map[string]bool{"help": true, "h": true}
+	for _, cmd := range builtInCommands {
+		for _, name := range cmd.Names() {
+			builtInNames[name] = true
+		}
+	}
+	pluginsPerName := map[string]int{}
+	for _, sig := range signatures {
+		pluginsPerName[sig.Name]++
+	}
+
+	var filtered []*pluginSignature
+	for _, sig := range signatures {
+		pluginName := getPluginNameFromPath(sig.ExecutablePath)
+		switch {
+		case builtInNames[sig.Name]:
+			logCollidingPlugin(pluginName, "collides with the built-in '"+sig.Name+"' command")
+		case pluginsPerName[sig.Name] > 1:
+			logCollidingPlugin(pluginName, "collides with another installed plugin named '"+sig.Name+"'")
+		default:
+			filtered = append(filtered, sig)
+		}
+	}
+	return filtered
+}
+
+func logCollidingPlugin(pluginName, reason string) {
+	log.Warn(fmt.Sprintf("%sthe command name of plugin '%s' %s, and will therefore not be registered as a command. "+
+		"To run it, use 'jfrog plugin run %s'.", pluginsErrorPrefix, pluginName, reason, pluginName))
+}
+
+// Returns the signature of an installed plugin.
+// The plugin is searched by its installed name first, and then by its command name.
+func GetPluginSignature(name string) (*components.PluginSignature, error) {
+	signatures, err := getPluginsSignatures()
+	if err != nil && len(signatures) == 0 {
+		return nil, err
+	}
+	var matchingCommandName []*components.PluginSignature
+	for _, sig := range signatures {
+		if getPluginNameFromPath(sig.ExecutablePath) == name {
+			return &sig.PluginSignature, nil
+		}
+		if sig.Name == name {
+			matchingCommandName = append(matchingCommandName, &sig.PluginSignature)
+		}
+	}
+	switch len(matchingCommandName) {
+	case 0:
+		return nil, errorutils.CheckError(errors.New("plugin '" + name + "' could not be found"))
+	case 1:
+		return matchingCommandName[0], nil
+	default:
+		return nil, errorutils.CheckError(errors.New("more than one plugin is named '" + name + "'. Run the plugin using its installed name instead"))
+	}
+}
+
+// Returns the installed name of a plugin, which is its executable's file name without the extension.
+func getPluginNameFromPath(execPath string) string {
+	fileName := filepath.Base(execPath)
+	return strings.TrimSuffix(fileName, filepath.Ext(fileName))
+}
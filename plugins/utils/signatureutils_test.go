@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/plugins/components"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveCollidingSignatures(t *testing.T) {
+	builtInCommands := []cli.Command{
+		{Name: "rt"},
+		{Name: "config", Aliases: []string{"c"}},
+	}
+	signatures := []*pluginSignature{
+		createSignature("rt-fs", "rt-fs"),
+		createSignature("rt", "rt-override"),
+		createSignature("c", "config-alias"),
+		createSignature("help", "help-override"),
+		createSignature("hello-frog", "hello-frog"),
+		createSignature("hello-frog", "hello-frog-copy"),
+	}
+
+	filtered := removeCollidingSignatures(signatures, builtInCommands)
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "rt-fs", filtered[0].Name)
+}
+
+func TestGetPluginNameFromPath(t *testing.T) {
+	assert.Equal(t, "hello-frog", getPluginNameFromPath(filepath.Join("plugins", "hello-frog")))
+	assert.Equal(t, "hello-frog", getPluginNameFromPath(filepath.Join("plugins", "hello-frog.exe")))
+}
+
+func createSignature(commandName, pluginName string) *pluginSignature {
+	return &pluginSignature{PluginSignature: components.PluginSignature{Name: commandName, ExecutablePath: filepath.Join("plugins", pluginName)}}
+}
@@ -0,0 +1,213 @@
+// Package audit records the mutating commands, such as 'rt delete' and 'rt repo-delete', in a local audit log.
+// The audit log is opt-in, and is enabled by setting the JFROG_CLI_AUDIT_LOG environment variable to true.
+// It is written as JSON lines to the audit directory under the JFrog home dir, and is rotated when it grows too large.
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/utils/lock"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/redact"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	// Set to true to record the mutating commands in the audit log.
+	AuditLogEnv = "JFROG_CLI_AUDIT_LOG"
+
+	auditDirName  = "audit"
+	auditFileName = "audit"
+	auditFileExt  = ".jsonl"
+	// The audit file is rotated when it exceeds this size. The rotated files are named audit.1.jsonl, audit.2.jsonl and so on,
+	// from the newest to the oldest.
+	maxFileSize = 10 * 1024 * 1024
+	maxBackups  = 5
+)
+
+// The commands recorded in the audit log, which create, change or delete artifacts, builds, repositories or permissions.
+// 'rt curl' is recorded as well, since it may send any request to the server.
+var auditedCommands = map[string]bool{
+	"rt upload":                    true,
+	"rt mvn":                       true,
+	"rt gradle":                    true,
+	"rt npm-publish":               true,
+	"rt go-publish":                true,
+	"rt docker-push":               true,
+	"rt podman-push":               true,
+	"rt move":                      true,
+	"rt copy":                      true,
+	"rt delete":                    true,
+	"rt set-props":                 true,
+	"rt delete-props":              true,
+	"rt build-publish":             true,
+	"rt build-promote":             true,
+	"rt build-discard":             true,
+	"rt build-distribute":          true,
+	"rt docker-promote":            true,
+	"rt git-lfs-clean":             true,
+	"rt release-bundle-create":     true,
+	"rt release-bundle-update":     true,
+	"rt release-bundle-sign":       true,
+	"rt release-bundle-distribute": true,
+	"rt release-bundle-delete":     true,
+	"rt repo-create":               true,
+	"rt repo-update":               true,
+	"rt repo-delete":               true,
+	"rt replication-create":        true,
+	"rt replication-delete":        true,
+	"rt permission-target-create":  true,
+	"rt permission-target-update":  true,
+	"rt permission-target-delete":  true,
+	"rt user-create":               true,
+	"rt users-create":              true,
+	"rt users-delete":              true,
+	"rt group-create":              true,
+	"rt group-add-users":           true,
+	"rt group-delete":              true,
+	"rt access-token-create":       true,
+	"rt curl":                      true,
+}
+
+// A single command recorded in the audit log.
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	// The user of the operating system who ran the command.
+	User string `json:"user"`
+	// The user the command authenticated as, if the server was configured with a user.
+	ServerUser string `json:"serverUser,omitempty"`
+	ServerId   string `json:"serverId,omitempty"`
+	Command    string `json:"command"`
+	// The command arguments and options, with the credentials masked.
+	Args []string `json:"args"`
+	// The number of artifacts affected by the command, for the commands which report it in their summary.
+	AffectedCount *int   `json:"affectedCount,omitempty"`
+	ExitCode      int    `json:"exitCode"`
+	Error         string `json:"error,omitempty"`
+}
+
+func IsEnabled() bool {
+	enabled, err := clientutils.GetBoolEnvValue(AuditLogEnv, false)
+	return err == nil && enabled
+}
+
+func IsAuditedCommand(commandName string) bool {
+	return auditedCommands[commandName]
+}
+
+// Records the command in the audit log, if the audit log is enabled and the command is a mutating command.
+// args are the command line arguments, excluding the executable. err is the error the command returned.
+// report is the report of the command, holding the server it used and its summary.
+func RecordCommand(commandName string, args []string, exitCode int, err error, report *cliutils.CommandReport) error {
+	if !IsEnabled() || !IsAuditedCommand(commandName) {
+		return nil
+	}
+	return Write(NewRecord(commandName, args, exitCode, err, report))
+}
+
+// Creates the audit record of the command which has just ended.
+// The server details and the affected artifacts count are taken from the command's report.
+func NewRecord(commandName string, args []string, exitCode int, err error, report *cliutils.CommandReport) *Record {
+	record := &Record{Timestamp: time.Now().UTC(), User: getOsUser(), Command: commandName, Args: redact.Args(args), ExitCode: exitCode}
+	if report.ServerDetails != nil {
+		record.ServerId = report.ServerDetails.ServerId
+		record.ServerUser = report.ServerDetails.User
+	}
+	if totals := getCommandTotals(report.Result); totals != nil {
+		affectedCount := totals.Success
+		record.AffectedCount = &affectedCount
+	}
+	if err != nil {
+		record.Error = redact.String(err.Error())
+	}
+	return record
+}
+
+// Returns the totals of the summary the command reported, or nil if the command reported no summary.
+func getCommandTotals(result interface{}) *summary.Totals {
+	switch commandSummary := result.(type) {
+	case *summary.Summary:
+		return commandSummary.Totals
+	case *summary.BuildInfoSummary:
+		return commandSummary.Totals
+	}
+	return nil
+}
+
+func getOsUser() string {
+	if currentUser, err := user.Current(); err == nil {
+		return currentUser.Username
+	}
+	if username := os.Getenv("USER"); username != "" {
+		return username
+	}
+	return os.Getenv("USERNAME")
+}
+
+// Appends the record to the audit log, rotating the audit log if it exceeds its maximal size.
+func Write(record *Record) error {
+	auditDir, err := coreutils.CreateDirInJfrogHome(auditDirName)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(record)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	// Synchronize with other CLI processes, which may write or rotate the audit log concurrently.
+	lockFile, err := lock.CreateLock()
+	if err != nil {
+		return err
+	}
+	defer lockFile.Unlock()
+	if err = rotateIfNeeded(auditDir, int64(len(content))+1); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(getAuditFilePath(auditDir, 0), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer file.Close()
+	_, err = file.Write(append(content, '\n'))
+	return errorutils.CheckError(err)
+}
+
+// Rotates the audit log if appending the given number of bytes would exceed its maximal size.
+// The oldest rotated file is deleted.
+func rotateIfNeeded(auditDir string, appendSize int64) error {
+	info, err := os.Stat(getAuditFilePath(auditDir, 0))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if info.Size()+appendSize <= maxFileSize {
+		return nil
+	}
+	if err = os.Remove(getAuditFilePath(auditDir, maxBackups)); err != nil && !os.IsNotExist(err) {
+		return errorutils.CheckError(err)
+	}
+	for i := maxBackups - 1; i >= 0; i-- {
+		if err = os.Rename(getAuditFilePath(auditDir, i), getAuditFilePath(auditDir, i+1)); err != nil && !os.IsNotExist(err) {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
+// Returns the path of the audit file. Index 0 is the current audit file, and the other indexes are the rotated files.
+func getAuditFilePath(auditDir string, index int) string {
+	if index == 0 {
+		return filepath.Join(auditDir, auditFileName+auditFileExt)
+	}
+	return filepath.Join(auditDir, auditFileName+"."+strconv.Itoa(index)+auditFileExt)
+}
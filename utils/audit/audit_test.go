@@ -0,0 +1,230 @@
+package audit
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/artifactory"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/stretchr/testify/assert"
+)
+
+// Sets a temporary JFrog home dir, in which the audit log is written.
+func setTempHomeDir(t *testing.T) func() {
+	homeDir, err := ioutil.TempDir("", "audit")
+	assert.NoError(t, err)
+	assert.NoError(t, os.Setenv(coreutils.HomeDir, homeDir))
+	return func() {
+		assert.NoError(t, os.Unsetenv(coreutils.HomeDir))
+		assert.NoError(t, os.RemoveAll(homeDir))
+	}
+}
+
+func TestRecordCommand(t *testing.T) {
+	defer setTempHomeDir(t)()
+	args := []string{"rt", "del", "repo/*.zip", "--access-token=my-access-token", "--quiet"}
+	report := &cliutils.CommandReport{Result: summary.GetSummaryReport(3, 1, nil), ServerDetails: &config.ServerDetails{ServerId: "my-server", User: "admin"}}
+
+	// The audit log is disabled by default.
+	assert.NoError(t, RecordCommand("rt delete", args, 1, nil, report))
+	records, err := ReadRecords(&Filter{})
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+
+	assert.NoError(t, os.Setenv(AuditLogEnv, "true"))
+	defer os.Unsetenv(AuditLogEnv)
+	assert.NoError(t, RecordCommand("rt delete", args, 1, errors.New("failed deleting"), report))
+	// Read commands aren't recorded.
+	assert.NoError(t, RecordCommand("rt search", []string{"rt", "s", "repo/*.zip"}, 0, nil, report))
+
+	records, err = ReadRecords(&Filter{})
+	assert.NoError(t, err)
+	if !assert.Len(t, records, 1) {
+		return
+	}
+	record := records[0]
+	assert.Equal(t, "rt delete", record.Command)
+	assert.Equal(t, []string{"rt", "del", "repo/*.zip", "--access-token=***", "--quiet"}, record.Args)
+	assert.Equal(t, 3, *record.AffectedCount)
+	assert.Equal(t, "my-server", record.ServerId)
+	assert.Equal(t, "admin", record.ServerUser)
+	assert.Equal(t, 1, record.ExitCode)
+	assert.Equal(t, "failed deleting", record.Error)
+	assert.NotEmpty(t, record.User)
+	assert.WithinDuration(t, time.Now(), record.Timestamp, time.Minute)
+}
+
+func TestReadRecordsFilter(t *testing.T) {
+	defer setTempHomeDir(t)()
+	start := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	commands := []string{"rt upload", "rt delete", "rt repo-delete", "rt delete"}
+	for i, command := range commands {
+		assert.NoError(t, Write(&Record{Timestamp: start.Add(time.Duration(i) * time.Hour), Command: command}))
+	}
+
+	tests := []struct {
+		name     string
+		filter   *Filter
+		expected []string
+	}{
+		{"all", &Filter{}, commands},
+		{"command", &Filter{Command: "rt delete"}, []string{"rt delete", "rt delete"}},
+		{"commandWithoutNamespace", &Filter{Command: "repo-delete"}, []string{"rt repo-delete"}},
+		{"from", &Filter{From: start.Add(2 * time.Hour)}, []string{"rt repo-delete", "rt delete"}},
+		{"to", &Filter{To: start.Add(time.Hour)}, []string{"rt upload", "rt delete"}},
+		{"timeRangeAndCommand", &Filter{From: start.Add(time.Minute), To: start.Add(2 * time.Hour), Command: "delete"}, []string{"rt delete"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			records, err := ReadRecords(test.filter)
+			assert.NoError(t, err)
+			var actual []string
+			for _, record := range records {
+				actual = append(actual, record.Command)
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestRotation(t *testing.T) {
+	defer setTempHomeDir(t)()
+	assert.NoError(t, Write(&Record{Command: "rt upload"}))
+	auditDir, err := coreutils.CreateDirInJfrogHome(auditDirName)
+	assert.NoError(t, err)
+
+	// Fill the audit file up to its maximal size, so that the next record rotates it.
+	auditFile, err := os.OpenFile(getAuditFilePath(auditDir, 0), os.O_APPEND|os.O_WRONLY, 0600)
+	assert.NoError(t, err)
+	info, err := auditFile.Stat()
+	assert.NoError(t, err)
+	_, err = auditFile.Write(make([]byte, maxFileSize-info.Size()-1))
+	assert.NoError(t, err)
+	_, err = auditFile.Write([]byte("\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, auditFile.Close())
+
+	assert.NoError(t, Write(&Record{Command: "rt delete"}))
+	assert.FileExists(t, filepath.Join(auditDir, "audit.1.jsonl"))
+	records, err := ReadRecords(&Filter{})
+	assert.NoError(t, err)
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, "rt upload", records[0].Command)
+		assert.Equal(t, "rt delete", records[1].Command)
+	}
+
+	// The oldest backup is deleted when the maximal number of backups is reached.
+	for i := 2; i <= maxBackups+1; i++ {
+		assert.NoError(t, rotateIfNeeded(auditDir, maxFileSize))
+		assert.NoError(t, ioutil.WriteFile(getAuditFilePath(auditDir, 0), []byte("\n"), 0600))
+	}
+	for i := 1; i <= maxBackups; i++ {
+		assert.FileExists(t, getAuditFilePath(auditDir, i))
+	}
+	assert.NoFileExists(t, getAuditFilePath(auditDir, maxBackups+1))
+}
+
+func TestReadRecordsSkipsInvalidLines(t *testing.T) {
+	defer setTempHomeDir(t)()
+	auditDir, err := coreutils.CreateDirInJfrogHome(auditDirName)
+	assert.NoError(t, err)
+	content := `{"command":"rt upload","exitCode":0}` + "\n" + `{"command":"rt del` + "\n"
+	assert.NoError(t, ioutil.WriteFile(getAuditFilePath(auditDir, 0), []byte(content), 0600))
+	records, err := ReadRecords(&Filter{})
+	assert.NoError(t, err)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, "rt upload", records[0].Command)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2021, 3, 10, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		endOfDay bool
+		expected time.Time
+	}{
+		{"2021-03-01T10:00:00Z", false, time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{"2021-03-01", false, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"2021-03-01", true, time.Date(2021, 3, 1, 23, 59, 59, 999999999, time.UTC)},
+		{"24h", false, time.Date(2021, 3, 9, 12, 30, 0, 0, time.UTC)},
+		{"90m", true, time.Date(2021, 3, 10, 11, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			parsed, err := ParseTime(test.value, now, test.endOfDay)
+			assert.NoError(t, err)
+			assert.True(t, test.expected.Equal(parsed), "expected %s, got %s", test.expected, parsed)
+		})
+	}
+	for _, invalid := range []string{"yesterday", "2021-13-01", "-5h"} {
+		_, err := ParseTime(invalid, now, false)
+		assert.Error(t, err)
+	}
+}
+
+func TestIsAuditedCommand(t *testing.T) {
+	for _, command := range []string{"rt npm-publish", "rt go-publish", "rt docker-push", "rt podman-push", "rt release-bundle-create",
+		"rt release-bundle-update", "rt release-bundle-sign", "rt release-bundle-distribute"} {
+		assert.True(t, IsAuditedCommand(command), command)
+	}
+	assert.False(t, IsAuditedCommand("rt download"))
+}
+
+// The Artifactory commands which only read from the server, or only change local files, and are therefore not audited.
+var nonMutatingArtifactoryCommands = map[string]bool{
+	"build-add-dependencies":     true,
+	"build-add-git":              true,
+	"build-append":               true,
+	"build-clean":                true,
+	"build-collect-env":          true,
+	"build-docker-create":        true,
+	"build-scan":                 true,
+	"config":                     true,
+	"diff":                       true,
+	"docker-pull":                true,
+	"dotnet":                     true,
+	"dotnet-config":              true,
+	"download":                   true,
+	"go":                         true,
+	"go-config":                  true,
+	"gradle-config":              true,
+	"mvn-config":                 true,
+	"npm-ci":                     true,
+	"npm-config":                 true,
+	"npm-install":                true,
+	"nuget":                      true,
+	"nuget-config":               true,
+	"nuget-deps-tree":            true,
+	"permission-target-template": true,
+	"ping":                       true,
+	"pip-config":                 true,
+	"pip-install":                true,
+	"podman-pull":                true,
+	"replication-template":       true,
+	"repo-template":              true,
+	"search":                     true,
+	"use":                        true,
+	"yarn":                       true,
+	"yarn-config":                true,
+}
+
+// Asserts every Artifactory command which changes the server is audited.
+// A new command should be added either to the audited commands, or to the non mutating commands above.
+func TestAuditedArtifactoryCommands(t *testing.T) {
+	for _, cmd := range artifactory.GetCommands() {
+		commandName := "rt " + cmd.Name
+		if nonMutatingArtifactoryCommands[cmd.Name] {
+			assert.False(t, IsAuditedCommand(commandName), commandName+" is audited, but is listed as a non mutating command")
+			continue
+		}
+		assert.True(t, IsAuditedCommand(commandName), commandName+" is neither audited, nor listed as a non mutating command")
+	}
+}
@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The date format of the --from and --to options, in addition to RFC 3339 timestamps and durations.
+const dateFormat = "2006-01-02"
+
+// Selects the records of the audit log.
+type Filter struct {
+	// The records recorded before From or after To are excluded. Zero values aren't applied.
+	From time.Time
+	To   time.Time
+	// The name of the command, such as 'rt delete'. The 'rt' namespace may be omitted. Empty to include all the commands.
+	Command string
+}
+
+func (filter *Filter) matches(record *Record) bool {
+	if !filter.From.IsZero() && record.Timestamp.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && record.Timestamp.After(filter.To) {
+		return false
+	}
+	if filter.Command == "" {
+		return true
+	}
+	command := strings.Join(strings.Fields(filter.Command), " ")
+	return record.Command == command || strings.HasSuffix(record.Command, " "+command)
+}
+
+// Returns the records of the audit log which match the filter, from the oldest to the newest, including the rotated files.
+func ReadRecords(filter *Filter) ([]*Record, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return readRecords(filepath.Join(homeDir, auditDirName), filter)
+}
+
+func readRecords(auditDir string, filter *Filter) ([]*Record, error) {
+	var records []*Record
+	for i := maxBackups; i >= 0; i-- {
+		fileRecords, err := readFile(getAuditFilePath(auditDir, i), filter)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, fileRecords...)
+	}
+	return records, nil
+}
+
+func readFile(path string, filter *Filter) ([]*Record, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
+	var records []*Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxFileSize)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		record := &Record{}
+		if err = json.Unmarshal([]byte(line), record); err != nil {
+			// A line may be truncated if the CLI was killed while writing it. Skip it rather than failing.
+			log.Warn(fmt.Sprintf("Skipping line %d of the audit log %s, which isn't a valid record: %s", lineNumber, path, err.Error()))
+			continue
+		}
+		if filter.matches(record) {
+			records = append(records, record)
+		}
+	}
+	return records, errorutils.CheckError(scanner.Err())
+}
+
+// Parses the value of the --from and --to options, which is an RFC 3339 timestamp such as '2021-03-01T10:00:00Z',
+// a date such as '2021-03-01', or a duration before now such as '24h'.
+// The dates are in local time. If endOfDay is true, a date stands for the end of the day rather than its start.
+func ParseTime(value string, now time.Time, endOfDay bool) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	if parsed, err := time.ParseInLocation(dateFormat, value, now.Location()); err == nil {
+		if endOfDay {
+			return parsed.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+		}
+		return parsed, nil
+	}
+	if duration, err := time.ParseDuration(value); err == nil && duration >= 0 {
+		return now.Add(-duration), nil
+	}
+	return time.Time{}, errorutils.CheckError(errors.New("invalid time '" + value + "'. Expected an RFC 3339 timestamp such as '2021-03-01T10:00:00Z', a date such as '2021-03-01' or a duration such as '24h'"))
+}
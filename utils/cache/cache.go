@@ -0,0 +1,296 @@
+// Package cache implements the local download cache, which stores the downloaded files by their sha256 checksums,
+// so that the download command can copy them into the target directory instead of downloading them again.
+// The least recently used files are evicted when the cache exceeds its maximal size.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The directory of the download cache. The --cache-dir option overrides it.
+	DownloadCacheEnv = "JFROG_CLI_DOWNLOAD_CACHE"
+	// The maximal size of the download cache, such as 500MB or 20GB.
+	DownloadCacheMaxSizeEnv = "JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE"
+	DefaultMaxSize          = 10 * 1024 * 1024 * 1024
+)
+
+// The cache entries are named by the sha256 of their content, under a directory named by its first two characters.
+var entryNameRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+type Cache struct {
+	dir     string
+	maxSize int64
+}
+
+// Returns the directory of the download cache, which is the given directory if set, or the value of the JFROG_CLI_DOWNLOAD_CACHE environment variable.
+// Returns an empty string if the download cache isn't used.
+func GetDir(dir string) string {
+	if dir != "" {
+		return dir
+	}
+	return os.Getenv(DownloadCacheEnv)
+}
+
+// Opens the download cache in the directory, and creates the directory if needed.
+// The maximal size of the cache is read from the JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE environment variable.
+func New(dir string) (*Cache, error) {
+	var maxSize int64 = DefaultMaxSize
+	if value := os.Getenv(DownloadCacheMaxSizeEnv); value != "" {
+		var err error
+		if maxSize, err = ParseSize(value); err != nil {
+			return nil, errorutils.CheckError(errors.New("the " + DownloadCacheMaxSizeEnv + " environment variable is invalid: " + err.Error()))
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	return &Cache{dir: dir, maxSize: maxSize}, nil
+}
+
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+func (c *Cache) MaxSize() int64 {
+	return c.maxSize
+}
+
+func (c *Cache) entryPath(sha256 string) string {
+	return filepath.Join(c.dir, sha256[:2], sha256)
+}
+
+// Copies the cached file with the sha256 checksum and size to the local path, replacing the existing local file.
+// The file is copied rather than linked, so that changing the local file doesn't change the cached file.
+// The content is verified against the checksum while it's copied, and a corrupted entry is removed.
+// Returns false if the file isn't cached.
+func (c *Cache) Get(sha256 string, size int64, localPath string) (bool, error) {
+	if !entryNameRegex.MatchString(sha256) {
+		return false, nil
+	}
+	entryPath := c.entryPath(sha256)
+	info, err := os.Stat(entryPath)
+	if err != nil || info.Size() != size {
+		return false, nil
+	}
+	if err = os.MkdirAll(filepath.Dir(localPath), 0755); errorutils.CheckError(err) != nil {
+		return false, err
+	}
+	if err = os.Remove(localPath); err != nil && !os.IsNotExist(err) {
+		return false, errorutils.CheckError(err)
+	}
+	actualSha256, err := copyFile(entryPath, localPath)
+	if err != nil {
+		return false, err
+	}
+	if actualSha256 != sha256 {
+		log.Debug("The cached file", entryPath, "doesn't match its checksum, and is removed from the download cache.")
+		for _, path := range []string{localPath, entryPath} {
+			if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return false, errorutils.CheckError(err)
+			}
+		}
+		return false, nil
+	}
+	// The modification time of the entries is their last use, according to which they're evicted.
+	now := time.Now()
+	return true, errorutils.CheckError(os.Chtimes(entryPath, now, now))
+}
+
+// Adds the local file to the cache, if it isn't cached yet. The file isn't added if its content doesn't match the sha256 checksum.
+func (c *Cache) Add(sha256, localPath string) error {
+	if !entryNameRegex.MatchString(sha256) {
+		return nil
+	}
+	entryPath := c.entryPath(sha256)
+	if _, err := os.Stat(entryPath); err == nil {
+		now := time.Now()
+		return errorutils.CheckError(os.Chtimes(entryPath, now, now))
+	}
+	if err := os.MkdirAll(filepath.Dir(entryPath), 0755); errorutils.CheckError(err) != nil {
+		return err
+	}
+	// The file is copied to a temporary path first, so that other processes don't use a partially copied entry.
+	tempPath := fmt.Sprintf("%s.%d.tmp", entryPath, os.Getpid())
+	defer os.Remove(tempPath)
+	actualSha256, err := copyFile(localPath, tempPath)
+	if err != nil {
+		return err
+	}
+	if actualSha256 != sha256 {
+		log.Debug("The checksum of", localPath, "doesn't match the checksum in Artifactory. The file isn't added to the download cache.")
+		return nil
+	}
+	return errorutils.CheckError(os.Rename(tempPath, entryPath))
+}
+
+// Copies the file, and returns the sha256 checksum of the copied content.
+func copyFile(sourcePath, targetPath string) (string, error) {
+	source, err := os.Open(sourcePath)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	defer source.Close()
+	target, err := os.Create(targetPath)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(target, hash), source)
+	if closeErr := target.Close(); err == nil {
+		err = closeErr
+	}
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// A file in the cache.
+type entry struct {
+	path     string
+	size     int64
+	lastUsed time.Time
+}
+
+// Returns the cache entries, the least recently used first.
+func (c *Cache) readEntries() ([]*entry, error) {
+	var entries []*entry
+	dirs, err := ioutil.ReadDir(c.dir)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		if !dir.IsDir() || len(dir.Name()) != 2 {
+			continue
+		}
+		files, err := ioutil.ReadDir(filepath.Join(c.dir, dir.Name()))
+		if errorutils.CheckError(err) != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if file.Mode().IsRegular() && entryNameRegex.MatchString(file.Name()) {
+				entries = append(entries, &entry{path: filepath.Join(c.dir, dir.Name(), file.Name()), size: file.Size(), lastUsed: file.ModTime()})
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].lastUsed.Before(entries[j].lastUsed)
+	})
+	return entries, nil
+}
+
+// The content of the cache.
+type Stats struct {
+	Dir       string    `json:"dir"`
+	Files     int       `json:"files"`
+	Size      int64     `json:"size"`
+	MaxSize   int64     `json:"maxSize"`
+	OldestUse time.Time `json:"oldestUse,omitempty"`
+	LatestUse time.Time `json:"latestUse,omitempty"`
+}
+
+func (c *Cache) Stats() (*Stats, error) {
+	entries, err := c.readEntries()
+	if err != nil {
+		return nil, err
+	}
+	stats := &Stats{Dir: c.dir, Files: len(entries), MaxSize: c.maxSize}
+	for _, entry := range entries {
+		stats.Size += entry.size
+	}
+	if len(entries) > 0 {
+		stats.OldestUse = entries[0].lastUsed
+		stats.LatestUse = entries[len(entries)-1].lastUsed
+	}
+	return stats, nil
+}
+
+// Removes the files which weren't used since the given time, if it's set, and then the least recently used files,
+// until the size of the cache doesn't exceed the maximal size. Returns the number of removed files and their total size.
+func (c *Cache) Prune(maxSize int64, unusedSince time.Time) (removedFiles int, removedSize int64, err error) {
+	entries, err := c.readEntries()
+	if err != nil {
+		return
+	}
+	var totalSize int64
+	for _, entry := range entries {
+		totalSize += entry.size
+	}
+	for _, entry := range entries {
+		if totalSize <= maxSize && !entry.lastUsed.Before(unusedSince) {
+			break
+		}
+		if err = os.Remove(entry.path); err != nil && !os.IsNotExist(err) {
+			return removedFiles, removedSize, errorutils.CheckError(err)
+		}
+		err = nil
+		totalSize -= entry.size
+		removedFiles++
+		removedSize += entry.size
+	}
+	return
+}
+
+// Evicts the least recently used files, if the size of the cache exceeds its maximal size.
+func (c *Cache) Evict() error {
+	removedFiles, removedSize, err := c.Prune(c.maxSize, time.Time{})
+	if removedFiles > 0 {
+		log.Debug("Evicted", removedFiles, "files of", FormatSize(removedSize), "from the download cache.")
+	}
+	return err
+}
+
+var sizeUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"TB", 1024 * 1024 * 1024 * 1024},
+	{"GB", 1024 * 1024 * 1024},
+	{"MB", 1024 * 1024},
+	{"KB", 1024},
+	{"B", 1},
+}
+
+// Parses a size, such as 500MB or 20GB. A size without a unit is in bytes.
+func ParseSize(sizeValue string) (int64, error) {
+	value := strings.ToUpper(strings.TrimSpace(sizeValue))
+	unitSize := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			unitSize = unit.size
+			break
+		}
+	}
+	size, err := strconv.ParseFloat(value, 64)
+	if err != nil || size < 0 {
+		return 0, errors.New("invalid size: " + sizeValue + ". The size should be a number, with an optional unit, such as 500MB or 20GB")
+	}
+	return int64(size * float64(unitSize)), nil
+}
+
+// Formats a size in bytes, with the largest unit in which it's at least 1, such as 1.5GB.
+func FormatSize(size int64) string {
+	for _, unit := range sizeUnits {
+		if size >= unit.size {
+			return strings.TrimSuffix(strconv.FormatFloat(float64(size)/float64(unit.size), 'f', 1, 64), ".0") + unit.suffix
+		}
+	}
+	return "0B"
+}
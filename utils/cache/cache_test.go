@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+// The sha256 of 'a.zip'.
+const aSha256 = "d42cf35afe0bcfc26772d92fe13bf3cdaf9b8cf8f0df8eebf569e6a88536e72f"
+
+func createTempCache(t *testing.T) (*Cache, string, func()) {
+	log.SetLogger(log.NewLogger(log.INFO, nil))
+	tempDir, err := ioutil.TempDir("", "cache")
+	assert.NoError(t, err)
+	cache, err := New(filepath.Join(tempDir, "cache"))
+	assert.NoError(t, err)
+	return cache, tempDir, func() {
+		assert.NoError(t, os.RemoveAll(tempDir))
+	}
+}
+
+func TestAddAndGet(t *testing.T) {
+	cache, tempDir, cleanup := createTempCache(t)
+	defer cleanup()
+	downloadedPath := filepath.Join(tempDir, "workspace1", "a.zip")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(downloadedPath), 0755))
+	assert.NoError(t, ioutil.WriteFile(downloadedPath, []byte("a.zip"), 0644))
+
+	// A file which doesn't match its checksum isn't added.
+	assert.NoError(t, cache.Add(aSha256[1:]+"0", downloadedPath))
+	assert.NoError(t, cache.Add(aSha256, downloadedPath))
+	stats, err := cache.Stats()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.Files)
+	assert.Equal(t, int64(5), stats.Size)
+
+	// The cached file replaces the existing local file.
+	localPath := filepath.Join(tempDir, "workspace2", "dir", "a.zip")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(localPath), 0755))
+	assert.NoError(t, ioutil.WriteFile(localPath, []byte("old"), 0644))
+	hit, err := cache.Get(aSha256, 5, localPath)
+	assert.NoError(t, err)
+	assert.True(t, hit)
+	content, err := ioutil.ReadFile(localPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "a.zip", string(content))
+
+	// Changing the local file doesn't change the cached file.
+	assert.NoError(t, ioutil.WriteFile(localPath, []byte("b.zip"), 0644))
+	entryContent, err := ioutil.ReadFile(cache.entryPath(aSha256))
+	assert.NoError(t, err)
+	assert.Equal(t, "a.zip", string(entryContent))
+
+	// The size of the artifact must match the cached file.
+	hit, err = cache.Get(aSha256, 6, filepath.Join(tempDir, "workspace3", "a.zip"))
+	assert.NoError(t, err)
+	assert.False(t, hit)
+	hit, err = cache.Get("", 5, filepath.Join(tempDir, "workspace3", "a.zip"))
+	assert.NoError(t, err)
+	assert.False(t, hit)
+	assert.NoFileExists(t, filepath.Join(tempDir, "workspace3", "a.zip"))
+
+	// A corrupted entry isn't used, and is removed.
+	assert.NoError(t, ioutil.WriteFile(cache.entryPath(aSha256), []byte("c.zip"), 0644))
+	hit, err = cache.Get(aSha256, 5, filepath.Join(tempDir, "workspace3", "a.zip"))
+	assert.NoError(t, err)
+	assert.False(t, hit)
+	assert.NoFileExists(t, filepath.Join(tempDir, "workspace3", "a.zip"))
+	assert.NoFileExists(t, cache.entryPath(aSha256))
+}
+
+func TestPrune(t *testing.T) {
+	cache, _, cleanup := createTempCache(t)
+	defer cleanup()
+	now := time.Now()
+	// Three entries of 10 bytes, used an hour, two hours and three hours ago.
+	var entries []string
+	for i, sha256 := range []string{"1", "2", "3"} {
+		entryPath := cache.entryPath(sha256 + aSha256[1:])
+		assert.NoError(t, os.MkdirAll(filepath.Dir(entryPath), 0755))
+		assert.NoError(t, ioutil.WriteFile(entryPath, make([]byte, 10), 0644))
+		lastUsed := now.Add(-time.Duration(i+1) * time.Hour)
+		assert.NoError(t, os.Chtimes(entryPath, lastUsed, lastUsed))
+		entries = append(entries, entryPath)
+	}
+	stats, err := cache.Stats()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stats.Files)
+	assert.Equal(t, int64(30), stats.Size)
+	assert.WithinDuration(t, now.Add(-3*time.Hour), stats.OldestUse, time.Second)
+	assert.WithinDuration(t, now.Add(-time.Hour), stats.LatestUse, time.Second)
+
+	// Nothing is removed while the cache doesn't exceed its size.
+	removedFiles, _, err := cache.Prune(30, time.Time{})
+	assert.NoError(t, err)
+	assert.Zero(t, removedFiles)
+
+	// The least recently used entry is removed first.
+	removedFiles, removedSize, err := cache.Prune(25, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removedFiles)
+	assert.Equal(t, int64(10), removedSize)
+	assert.NoFileExists(t, entries[2])
+
+	// The entries unused for more than 90 minutes are removed.
+	removedFiles, _, err = cache.Prune(100, now.Add(-90*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removedFiles)
+	assert.NoFileExists(t, entries[1])
+	assert.FileExists(t, entries[0])
+}
+
+func TestMaxSizeEnv(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	cache, err := New(tempDir)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(DefaultMaxSize), cache.MaxSize())
+
+	assert.NoError(t, os.Setenv(DownloadCacheMaxSizeEnv, "500MB"))
+	defer os.Unsetenv(DownloadCacheMaxSizeEnv)
+	cache, err = New(tempDir)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(500*1024*1024), cache.MaxSize())
+
+	assert.NoError(t, os.Setenv(DownloadCacheMaxSizeEnv, "large"))
+	_, err = New(tempDir)
+	assert.Error(t, err)
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int64
+	}{
+		{"1024", 1024},
+		{"10B", 10},
+		{"2kb", 2048},
+		{"1.5GB", 1536 * 1024 * 1024},
+		{"20 GB", 20 * 1024 * 1024 * 1024},
+		{"1TB", 1024 * 1024 * 1024 * 1024},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			size, err := ParseSize(test.value)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, size)
+		})
+	}
+	for _, invalid := range []string{"", "GB", "-1GB", "10PB"} {
+		_, err := ParseSize(invalid)
+		assert.Error(t, err, invalid)
+	}
+	assert.Equal(t, "0B", FormatSize(0))
+	assert.Equal(t, "512B", FormatSize(512))
+	assert.Equal(t, "1.5GB", FormatSize(1536*1024*1024))
+	assert.Equal(t, "10GB", FormatSize(DefaultMaxSize))
+}
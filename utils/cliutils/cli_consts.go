@@ -13,6 +13,8 @@ const (
 	CmdCompletion     = "completion"
 	CmdPlugin         = "plugin"
 	CmdConfig         = "config"
+	CmdAudit          = "audit"
+	CmdCache          = "cache"
 
 	// Download
 	DownloadMinSplitKb    = 5120
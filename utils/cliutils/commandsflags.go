@@ -19,6 +19,7 @@ const (
 	Delete                  = "delete"
 	Properties              = "properties"
 	Search                  = "search"
+	Diff                    = "diff"
 	BuildPublish            = "build-publish"
 	BuildAppend             = "build-append"
 	BuildScan               = "build-scan"
@@ -85,6 +86,21 @@ const (
 	// Config commands keys
 	AddConfig  = "config-add"
 	EditConfig = "config-edit"
+	ShowConfig = "config-show"
+
+	// Audit commands keys
+	AuditShow = "audit-show"
+
+	// Cache commands keys
+	CachePrune = "cache-prune"
+	CacheStats = "cache-stats"
+
+	// Plugins commands keys
+	PluginInstall = "plugin-install"
+	PluginPublish = "plugin-publish"
+	PluginList    = "plugin-list"
+	PluginUpgrade = "plugin-upgrade"
+	PluginSync    = "plugin-sync"
 
 	// *** Artifactory Commands' flags ***
 	// Base flags
@@ -152,12 +168,19 @@ const (
 	bundle           = "bundle"
 	archiveEntries   = "archive-entries"
 	detailedSummary  = "detailed-summary"
+	summaryFile      = "summary-file"
+	summaryFormat    = "summary-format"
 	archive          = "archive"
 	syncDeletesQuiet = syncDeletes + "-" + quiet
 	antFlag          = "ant"
 	fromRt           = "from-rt"
 	transitive       = "transitive"
 
+	// Output flags, shared by the commands which render their results using an OutputWriter
+	outputFormat = "output-format"
+	outputFields = "output-fields"
+	format       = "format"
+
 	// Config flags
 	interactive   = "interactive"
 	encPassword   = "enc-password"
@@ -179,6 +202,8 @@ const (
 	deb                   = "deb"
 	symlinks              = "symlinks"
 	uploadAnt             = uploadPrefix + antFlag
+	stateFile             = "state-file"
+	verifyState           = "verify-state"
 
 	// Unique download flags
 	downloadPrefix       = "download-"
@@ -191,6 +216,9 @@ const (
 	minSplit             = "min-split"
 	splitCount           = "split-count"
 	validateSymlinks     = "validate-symlinks"
+	downloadLock         = "lock"
+	writeDownloadLock    = "write-lock"
+	resumable            = "resumable"
 
 	// Unique move flags
 	movePrefix       = "move-"
@@ -221,6 +249,10 @@ const (
 	count              = "count"
 	searchTransitive   = searchPrefix + transitive
 
+	// Unique diff flags
+	remoteSource   = "remote-source"
+	sourceServerId = "source-server-id"
+
 	// Unique properties flags
 	propertiesPrefix  = "props-"
 	propsRecursive    = propertiesPrefix + recursive
@@ -414,6 +446,31 @@ const (
 	configPassword    = configPrefix + password
 	configApiKey      = configPrefix + apikey
 	configInsecureTls = configPrefix + insecureTls
+
+	// *** Audit Commands' flags ***
+	auditPrefix  = "audit-"
+	auditFrom    = auditPrefix + from
+	auditTo      = auditPrefix + to
+	auditCommand = auditPrefix + "command"
+
+	// *** Cache Commands' flags ***
+	cacheDir       = "cache-dir"
+	cacheMaxSize   = "max-size"
+	cacheUnusedFor = "unused-for"
+
+	// *** Plugins Commands' flags ***
+	// Unique plugin-install flags
+	verify = "verify"
+
+	// Unique plugin-publish flags
+	signingKey = "signing-key"
+	arch       = "arch"
+
+	// Unique plugin-upgrade flags
+	all = "all"
+
+	// Unique plugin-sync flags
+	updateLock = "update-lock"
 )
 
 var flagsMap = map[string]cli.Flag{
@@ -591,6 +648,14 @@ var flagsMap = map[string]cli.Flag{
 		Name:  detailedSummary,
 		Usage: "[Default: false] Set to true to include a list of the affected files in the command summary.` `",
 	},
+	summaryFile: cli.StringFlag{
+		Name:  summaryFile,
+		Usage: "[Optional] Path to a file to write the command summary and the affected files to, in the format set by the --summary-format option.` `",
+	},
+	summaryFormat: cli.StringFlag{
+		Name:  summaryFormat,
+		Usage: "[Default: junit] The format of the summary file. Acceptable values are: junit and markdown. A markdown summary is appended to the file, so it can be added to the job summary of the CI system, such as $GITHUB_STEP_SUMMARY.` `",
+	},
 	interactive: cli.BoolTFlag{
 		Name:  interactive,
 		Usage: "[Default: true, unless $CI is true] Set to false if you do not want the config command to be interactive. If true, the --url option becomes optional.` `",
@@ -658,6 +723,14 @@ var flagsMap = map[string]cli.Flag{
 		Name:  archive,
 		Usage: "[Optional] Set to \"zip\" to deploy the files to Artifactory in a ZIP archive.` `",
 	},
+	stateFile: cli.StringFlag{
+		Name:  stateFile,
+		Usage: "[Optional] Path to a state file, in which the size, modification time and sha256 of the uploaded files are recorded. Files which weren't changed since they were uploaded with the same state file are skipped, without any request to Artifactory, unless more than 1000 files were changed, in which case all the files are uploaded. Can't be used with the --sync-deletes, --archive, --explode, --include-dirs and --symlinks options, or with build-info collection.` `",
+	},
+	verifyState: cli.StringFlag{
+		Name:  verifyState,
+		Usage: "[Optional] Duration, such as 24h. Files recorded in the state file which weren't verified in this duration are searched in Artifactory, and uploaded again if they're missing or their checksum differs. Set to 0 to verify all the recorded files. Requires the --state-file option.` `",
+	},
 	syncDeletesQuiet: cli.BoolFlag{
 		Name:  quiet,
 		Usage: "[Default: $CI] Set to true to skip the sync-deletes confirmation message.` `",
@@ -688,6 +761,18 @@ var flagsMap = map[string]cli.Flag{
 		Name:  validateSymlinks,
 		Usage: "[Default: false] Set to true to perform a checksum validation when downloading symbolic links.` `",
 	},
+	downloadLock: cli.StringFlag{
+		Name:  downloadLock,
+		Usage: "[Optional] Path to a lock file written by the --write-lock option. Downloads exactly the artifacts recorded in the lock file, to their recorded local paths, fails before downloading if the sha256 of any of them in Artifactory differs from the recorded sha256, and fails if the sha256 of any downloaded file differs from it. Can't be used with arguments, or with the --spec, --build and --bundle options.` `",
+	},
+	writeDownloadLock: cli.StringFlag{
+		Name:  writeDownloadLock,
+		Usage: "[Optional] Path to a lock file, in which the artifacts the download resolved to are recorded, with their sha256 and local paths. The lock file isn't written if the sha256 of any downloaded file differs from the recorded sha256. Downloading with the --lock option then downloads exactly the same artifacts. Can't be used to download the artifacts of builds or bundles.` `",
+	},
+	resumable: cli.BoolFlag{
+		Name:  resumable,
+		Usage: "[Default: false] Set to true to download the files which are split into ranges before the other files, one file at a time, recording the downloaded ranges in a journal next to the file. An interrupted download then resumes from the missing ranges when the command runs again with this option.` `",
+	},
 	downloadProps: cli.StringFlag{
 		Name:  props,
 		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Only artifacts with these properties will be downloaded.` `",
@@ -768,6 +853,14 @@ var flagsMap = map[string]cli.Flag{
 		Name:  transitive,
 		Usage: "[Default: false] Set to true to look for artifacts also in remote repositories. Available on Artifactory version 7.17.0 or higher.` `",
 	},
+	remoteSource: cli.BoolFlag{
+		Name:  remoteSource,
+		Usage: "[Default: false] Set to true to compare two repository paths, rather than a local directory with a repository path.` `",
+	},
+	sourceServerId: cli.StringFlag{
+		Name:  sourceServerId,
+		Usage: "[Optional] Server ID of the source repository path, if it differs from the server of the target repository path. Implies --remote-source.` `",
+	},
 	propsRecursive: cli.BoolTFlag{
 		Name:  recursive,
 		Usage: "[Default: true] When false, artifacts inside sub-folders in Artifactory will not be affected.` `",
@@ -1253,6 +1346,59 @@ var flagsMap = map[string]cli.Flag{
 		Name:  insecureTls,
 		Usage: "[Default: false] Set to true to skip TLS certificates verification, while encrypting the Artifactory password during the config process.` `",
 	},
+	// Plugins commands Flags
+	verify: cli.BoolFlag{
+		Name:  verify,
+		Usage: "[Default: false] Set to true to fail if the plugin's checksum manifest or signature were not published, or if no trusted keyring exists. A checksum mismatch or an invalid signature always fail the command.` `",
+	},
+	updateLock: cli.BoolFlag{
+		Name:  updateLock,
+		Usage: "[Default: false] Set to true to add the sha256 checksums of the installed plugins to the plugins lock file, for the plugins which have no checksum pinned for the local architecture.` `",
+	},
+	signingKey: cli.StringFlag{
+		Name:  signingKey,
+		Usage: "[Optional] Path to an armored GPG private key, used to create a detached signature for every published executable.` `",
+	},
+	arch: cli.StringFlag{
+		Name:  arch,
+		Usage: "[Default: all supported architectures] Comma separated list of architectures to build and publish the plugin for, such as: linux-amd64,linux-arm64. The latest version of the plugin is updated only when publishing for all the supported architectures.` `",
+	},
+	outputFormat: cli.StringFlag{
+		Name:  format,
+		Usage: "[Optional] Defines the output format of the command. Acceptable values are: table, json, jsonl, csv and yaml.` `",
+	},
+	outputFields: cli.StringFlag{
+		Name:  "fields",
+		Usage: "[Optional] Comma separated list of the fields to output, such as: path,size,modified. In the table and csv formats, these fields are the output's columns.` `",
+	},
+	auditFrom: cli.StringFlag{
+		Name:  from,
+		Usage: "[Optional] Show only the commands recorded since this time. Accepts an RFC 3339 timestamp such as 2021-03-01T10:00:00Z, a date in YYYY-MM-DD format, or a duration before now such as 24h.` `",
+	},
+	auditTo: cli.StringFlag{
+		Name:  to,
+		Usage: "[Optional] Show only the commands recorded until this time, in the format of the --from option. A date stands for the end of that day.` `",
+	},
+	auditCommand: cli.StringFlag{
+		Name:  "command",
+		Usage: "[Optional] Show only the records of this command, such as 'rt delete' or 'repo-delete'.` `",
+	},
+	cacheDir: cli.StringFlag{
+		Name:  cacheDir,
+		Usage: "[Default: $JFROG_CLI_DOWNLOAD_CACHE] Path to the local download cache, in which the downloaded files are stored by their sha256 checksums, and from which they're copied instead of being downloaded again.` `",
+	},
+	cacheMaxSize: cli.StringFlag{
+		Name:  cacheMaxSize,
+		Usage: "[Default: $JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE or 10GB] Remove the least recently used files until the size of the cache doesn't exceed this size, such as 500MB or 20GB.` `",
+	},
+	cacheUnusedFor: cli.StringFlag{
+		Name:  cacheUnusedFor,
+		Usage: "[Optional] Remove the files which weren't used for this duration, such as 720h.` `",
+	},
+	all: cli.BoolFlag{
+		Name:  all,
+		Usage: "[Default: false] Set to true to upgrade all the installed plugins.` `",
+	},
 }
 
 var commandFlags = map[string][]string{
@@ -1269,15 +1415,27 @@ var commandFlags = map[string][]string{
 		interactive, encPassword, url, distUrl, user, password, apikey, accessToken, sshKeyPath, clientCertPath,
 		clientCertKeyPath, basicAuthOnly, configInsecureTls,
 	},
+	ShowConfig: {
+		outputFormat, outputFields,
+	},
 	DeleteConfig: {
 		deleteQuiet,
 	},
+	AuditShow: {
+		auditFrom, auditTo, auditCommand, outputFormat, outputFields,
+	},
+	CachePrune: {
+		cacheDir, cacheMaxSize, cacheUnusedFor,
+	},
+	CacheStats: {
+		cacheDir, outputFormat, outputFields,
+	},
 	Upload: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath, targetProps,
 		clientCertKeyPath, spec, specVars, buildName, buildNumber, module, uploadExcludePatterns, uploadExclusions, deb,
 		uploadRecursive, uploadFlat, uploadRegexp, retries, dryRun, uploadExplode, symlinks, includeDirs,
 		uploadProps, failNoOp, threads, uploadSyncDeletes, syncDeletesQuiet, insecureTls, detailedSummary, project,
-		uploadAnt, uploadArchive,
+		uploadAnt, uploadArchive, summaryFile, summaryFormat, stateFile, verifyState,
 	},
 	Download: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
@@ -1285,40 +1443,46 @@ var commandFlags = map[string][]string{
 		sortOrder, limit, offset, downloadRecursive, downloadFlat, build, includeDeps, excludeArtifacts, minSplit, splitCount,
 		retries, dryRun, downloadExplode, validateSymlinks, bundle, includeDirs, downloadProps, downloadExcludeProps,
 		failNoOp, threads, archiveEntries, downloadSyncDeletes, syncDeletesQuiet, insecureTls, detailedSummary, project,
+		summaryFile, summaryFormat, cacheDir, downloadLock, writeDownloadLock, resumable,
 	},
 	Move: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, spec, specVars, excludePatterns, exclusions, sortBy, sortOrder, limit, offset, moveRecursive,
 		moveFlat, dryRun, build, includeDeps, excludeArtifacts, moveProps, moveExcludeProps, failNoOp, threads, archiveEntries,
-		insecureTls, retries,
+		insecureTls, retries, detailedSummary, summaryFile, summaryFormat,
 	},
 	Copy: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, spec, specVars, excludePatterns, exclusions, sortBy, sortOrder, limit, offset, copyRecursive,
 		copyFlat, dryRun, build, includeDeps, excludeArtifacts, bundle, copyProps, copyExcludeProps, failNoOp, threads,
-		archiveEntries, insecureTls, retries,
+		archiveEntries, insecureTls, retries, detailedSummary, summaryFile, summaryFormat,
 	},
 	Delete: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, spec, specVars, excludePatterns, exclusions, sortBy, sortOrder, limit, offset,
 		deleteRecursive, dryRun, build, includeDeps, excludeArtifacts, deleteQuiet, deleteProps, deleteExcludeProps, failNoOp, threads, archiveEntries,
-		insecureTls, retries,
+		insecureTls, retries, detailedSummary, summaryFile, summaryFormat,
 	},
 	Search: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, spec, specVars, excludePatterns, exclusions, sortBy, sortOrder, limit, offset,
 		searchRecursive, build, includeDeps, excludeArtifacts, count, bundle, includeDirs, searchProps, searchExcludeProps, failNoOp, archiveEntries,
-		insecureTls, searchTransitive, retries,
+		insecureTls, searchTransitive, retries, outputFormat, outputFields,
+	},
+	Diff: {
+		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, spec, specVars, uploadExclusions, uploadRecursive, uploadFlat, uploadRegexp, uploadAnt,
+		remoteSource, sourceServerId, insecureTls, retries, outputFormat, outputFields,
 	},
 	Properties: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, spec, specVars, excludePatterns, exclusions, sortBy, sortOrder, limit, offset,
 		propsRecursive, build, includeDeps, excludeArtifacts, bundle, includeDirs, failNoOp, threads, archiveEntries, propsProps, propsExcludeProps,
-		insecureTls, retries,
+		insecureTls, retries, detailedSummary,
 	},
 	BuildPublish: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, buildUrl, bpDryRun,
-		envInclude, envExclude, insecureTls, project, bpDetailedSummary,
+		envInclude, envExclude, insecureTls, project, bpDetailedSummary, summaryFile, summaryFormat,
 	},
 	BuildAppend: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, buildUrl, bpDryRun,
@@ -1427,7 +1591,7 @@ var commandFlags = map[string][]string{
 	},
 	Ping: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
-		clientCertKeyPath, insecureTls,
+		clientCertKeyPath, insecureTls, outputFormat, outputFields,
 	},
 	RtCurl: {
 		serverId,
@@ -1526,6 +1690,22 @@ var commandFlags = map[string][]string{
 	JpdDelete: {
 		mcUrl, mcAccessToken,
 	},
+	// Plugins commands
+	PluginInstall: {
+		verify,
+	},
+	PluginPublish: {
+		signingKey, arch, threads,
+	},
+	PluginList: {
+		outputFormat, outputFields,
+	},
+	PluginUpgrade: {
+		all, verify,
+	},
+	PluginSync: {
+		verify, updateLock,
+	},
 }
 
 func GetCommandFlags(cmd string) []cli.Flag {
@@ -1545,6 +1725,12 @@ func buildAndSortFlags(keys []string) (flags []cli.Flag) {
 	return
 }
 
+// Returns the flags of the commands which render their results using an OutputWriter.
+// Used by commands which don't declare their flags in the commands flags map.
+func GetOutputFlags() []cli.Flag {
+	return buildAndSortFlags([]string{outputFormat, outputFields})
+}
+
 // This function is used for mvn and gradle command validation
 func GetBasicBuildToolsFlags() (flags []cli.Flag) {
 	basicBuildToolsFlags := []string{url, distUrl, user, password, apikey, accessToken, serverId}
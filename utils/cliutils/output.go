@@ -0,0 +1,386 @@
+package cliutils
+
+import (
+	"bytes"
+	csvencoding "encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/codegangsta/cli"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+// The output formats the read commands can render their results in, using the --format option.
+type OutputFormat string
+
+const (
+	Table     OutputFormat = "table"
+	Json      OutputFormat = "json"
+	JsonLines OutputFormat = "jsonl"
+	Csv       OutputFormat = "csv"
+	Yaml      OutputFormat = "yaml"
+)
+
+var outputFormats = []OutputFormat{Table, Json, JsonLines, Csv, Yaml}
+
+// The output format and fields requested by the user.
+type OutputOptions struct {
+	Format OutputFormat
+	// The fields of each record to output, in their order.
+	// Empty if all fields should be output.
+	Fields []string
+}
+
+// Returns the output options requested using the --format and --fields options.
+// defaultFormat is used if the --format option isn't set.
+// availableFields are the fields the user is allowed to request. If empty, any field is allowed.
+// defaultFields are the columns of the table and CSV formats, if the --fields option isn't set.
+func GetOutputOptions(c *cli.Context, defaultFormat OutputFormat, availableFields, defaultFields []string) (*OutputOptions, error) {
+	options := &OutputOptions{Format: defaultFormat}
+	if c.IsSet("format") {
+		format, err := parseOutputFormat(c.String("format"))
+		if err != nil {
+			return nil, err
+		}
+		options.Format = format
+	}
+	if c.IsSet("fields") {
+		fields, err := parseOutputFields(c.String("fields"), availableFields)
+		if err != nil {
+			return nil, err
+		}
+		options.Fields = fields
+	} else if options.Format == Table || options.Format == Csv {
+		options.Fields = defaultFields
+	}
+	return options, nil
+}
+
+func parseOutputFormat(format string) (OutputFormat, error) {
+	var formats []string
+	for _, outputFormat := range outputFormats {
+		if OutputFormat(strings.ToLower(format)) == outputFormat {
+			return outputFormat, nil
+		}
+		formats = append(formats, string(outputFormat))
+	}
+	return "", errorutils.CheckError(fmt.Errorf("the --format option accepts the following values: %s. Got '%s'", strings.Join(formats, ", "), format))
+}
+
+func parseOutputFields(fields string, availableFields []string) ([]string, error) {
+	var parsed []string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if len(availableFields) > 0 && !isStringInSlice(field, availableFields) {
+			return nil, errorutils.CheckError(fmt.Errorf("unknown field '%s'. The available fields are: %s", field, strings.Join(availableFields, ", ")))
+		}
+		parsed = append(parsed, field)
+	}
+	if len(parsed) == 0 {
+		return nil, errorutils.CheckError(errors.New("the --fields option should include at least one field"))
+	}
+	return parsed, nil
+}
+
+// Renders a list of records in the requested output format.
+// The records are written as they arrive, except for the table format, which is written on Close to align its columns.
+// Records are structs or maps, which are converted to their JSON representation to extract their fields.
+type OutputWriter struct {
+	options *OutputOptions
+	// Buffers the previous JSON record, to know whether it should be followed by a comma.
+	pendingJson string
+	count       int
+	table       *tabwriter.Writer
+	tableBuffer bytes.Buffer
+}
+
+func NewOutputWriter(options *OutputOptions) *OutputWriter {
+	ow := &OutputWriter{options: options}
+	if options.Format == Table {
+		ow.table = tabwriter.NewWriter(&ow.tableBuffer, 0, 0, 3, ' ', 0)
+	}
+	return ow
+}
+
+func (ow *OutputWriter) Write(record interface{}) error {
+	fields, err := newOutputRecord(record, ow.options.Fields)
+	if err != nil {
+		return err
+	}
+	first := ow.count == 0
+	ow.count++
+	switch ow.options.Format {
+	case Table:
+		if first {
+			fmt.Fprintln(ow.table, strings.Join(toTableHeaders(fields.keys), "\t"))
+		}
+		fmt.Fprintln(ow.table, strings.Join(fields.stringValues(), "\t"))
+	case Csv:
+		if first {
+			if err = writeCsvRow(fields.keys); err != nil {
+				return err
+			}
+		}
+		return writeCsvRow(fields.stringValues())
+	case JsonLines:
+		content, err := json.Marshal(fields)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(string(content))
+	case Yaml:
+		content, err := yaml.Marshal([]interface{}{fields})
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(strings.TrimSuffix(string(content), "\n"))
+	default:
+		content, err := json.Marshal(fields)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if first {
+			log.Output("[")
+		} else {
+			log.Output(ow.pendingJson + ",")
+		}
+		ow.pendingJson = "  " + clientutils.IndentJsonArray(content)
+	}
+	return nil
+}
+
+// Completes the output. Must be called after all records were written.
+func (ow *OutputWriter) Close() error {
+	switch ow.options.Format {
+	case Table:
+		if ow.count == 0 {
+			return nil
+		}
+		if err := ow.table.Flush(); err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(strings.TrimSuffix(ow.tableBuffer.String(), "\n"))
+	case Yaml:
+		if ow.count == 0 {
+			log.Output("[]")
+		}
+	case Json:
+		if ow.count == 0 {
+			log.Output("[]")
+			return nil
+		}
+		log.Output(ow.pendingJson)
+		log.Output("]")
+	}
+	return nil
+}
+
+// Renders a single record, such as the details of a package, in the requested output format.
+// Unlike the OutputWriter, the JSON and YAML formats output the record itself, rather than a list.
+func PrintOutputRecord(options *OutputOptions, record interface{}) error {
+	switch options.Format {
+	case Json, Yaml:
+		fields, err := newOutputRecord(record, options.Fields)
+		if err != nil {
+			return err
+		}
+		if options.Format == Yaml {
+			content, err := yaml.Marshal(fields)
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+			log.Output(strings.TrimSuffix(string(content), "\n"))
+			return nil
+		}
+		content, err := json.Marshal(fields)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientutils.IndentJson(content))
+		return nil
+	default:
+		writer := NewOutputWriter(options)
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		return writer.Close()
+	}
+}
+
+// Converts the fields to the table's headers, such as 'serverId' and 'latest_version' to 'SERVER ID' and 'LATEST VERSION'.
+func toTableHeaders(fields []string) []string {
+	var headers []string
+	for _, field := range fields {
+		var header strings.Builder
+		for i, char := range field {
+			if char == '_' || char == '-' {
+				header.WriteRune(' ')
+				continue
+			}
+			if i > 0 && unicode.IsUpper(char) && unicode.IsLower(rune(field[i-1])) {
+				header.WriteRune(' ')
+			}
+			header.WriteRune(unicode.ToUpper(char))
+		}
+		headers = append(headers, header.String())
+	}
+	return headers
+}
+
+func writeCsvRow(values []string) error {
+	var builder strings.Builder
+	writer := csvencoding.NewWriter(&builder)
+	if err := writer.Write(values); err != nil {
+		return errorutils.CheckError(err)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(strings.TrimSuffix(builder.String(), "\n"))
+	return nil
+}
+
+// The fields of a record, in their original order.
+type outputRecord struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// Converts a record to its fields, using its JSON representation.
+// If fields are provided, only these fields are kept, in their provided order. Missing fields are empty.
+func newOutputRecord(record interface{}, fields []string) (*outputRecord, error) {
+	content, err := json.Marshal(record)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, errorutils.CheckError(fmt.Errorf("expecting a JSON object as an output record, got: %s", string(content)))
+	}
+	allFields := &outputRecord{values: map[string]interface{}{}}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		key := token.(string)
+		var value interface{}
+		if err = decoder.Decode(&value); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		allFields.keys = append(allFields.keys, key)
+		allFields.values[key] = normalizeNumbers(value)
+	}
+	if len(fields) == 0 {
+		return allFields, nil
+	}
+	selected := &outputRecord{keys: fields, values: map[string]interface{}{}}
+	for _, field := range fields {
+		selected.values[field] = allFields.values[field]
+	}
+	return selected, nil
+}
+
+// Converts JSON numbers to integers when possible, to keep them unquoted in the YAML output.
+func normalizeNumbers(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case json.Number:
+		if integer, err := typed.Int64(); err == nil {
+			return integer
+		}
+		if float, err := typed.Float64(); err == nil {
+			return float
+		}
+		return typed.String()
+	case map[string]interface{}:
+		for key, nested := range typed {
+			typed[key] = normalizeNumbers(nested)
+		}
+	case []interface{}:
+		for i, nested := range typed {
+			typed[i] = normalizeNumbers(nested)
+		}
+	}
+	return value
+}
+
+// Returns the values of the record as strings, for the table and CSV formats.
+// Nested values are rendered as compact JSON.
+func (record *outputRecord) stringValues() []string {
+	var values []string
+	for _, key := range record.keys {
+		switch value := record.values[key].(type) {
+		case nil:
+			values = append(values, "")
+		case string:
+			values = append(values, value)
+		case map[string]interface{}, []interface{}:
+			content, err := json.Marshal(value)
+			if err != nil {
+				content = []byte(fmt.Sprint(value))
+			}
+			values = append(values, string(content))
+		default:
+			values = append(values, fmt.Sprint(value))
+		}
+	}
+	return values
+}
+
+func (record *outputRecord) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("{")
+	for i, key := range record.keys {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+		if err := writeJsonValue(&buffer, key); err != nil {
+			return nil, err
+		}
+		buffer.WriteString(":")
+		if err := writeJsonValue(&buffer, record.values[key]); err != nil {
+			return nil, err
+		}
+	}
+	buffer.WriteString("}")
+	return buffer.Bytes(), nil
+}
+
+func writeJsonValue(writer io.Writer, value interface{}) error {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(content)
+	return err
+}
+
+func (record *outputRecord) MarshalYAML() (interface{}, error) {
+	mapSlice := yaml.MapSlice{}
+	for _, key := range record.keys {
+		mapSlice = append(mapSlice, yaml.MapItem{Key: key, Value: record.values[key]})
+	}
+	return mapSlice, nil
+}
+
+func isStringInSlice(str string, slice []string) bool {
+	for _, s := range slice {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
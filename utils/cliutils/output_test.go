@@ -0,0 +1,91 @@
+package cliutils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+type outputRecordMock struct {
+	Path  string              `json:"path"`
+	Size  int64               `json:"size"`
+	Props map[string][]string `json:"props,omitempty"`
+}
+
+var outputRecordsMock = []interface{}{
+	&outputRecordMock{Path: "repo/a.zip", Size: 10, Props: map[string][]string{"k": {"v"}}},
+	&outputRecordMock{Path: "repo/b, c.zip", Size: 20},
+}
+
+func TestOutputWriter(t *testing.T) {
+	tests := []struct {
+		format   OutputFormat
+		fields   []string
+		expected string
+	}{
+		{Json, nil, "[\n  {\n    \"path\": \"repo/a.zip\",\n    \"size\": 10,\n    \"props\": {\n      \"k\": [\n        \"v\"\n      ]\n    }\n  },\n" +
+			"  {\n    \"path\": \"repo/b, c.zip\",\n    \"size\": 20\n  }\n]\n"},
+		{JsonLines, []string{"size", "path"}, "{\"size\":10,\"path\":\"repo/a.zip\"}\n{\"size\":20,\"path\":\"repo/b, c.zip\"}\n"},
+		{Csv, []string{"path", "size", "props"}, "path,size,props\nrepo/a.zip,10,\"{\"\"k\"\":[\"\"v\"\"]}\"\n\"repo/b, c.zip\",20,\n"},
+		{Yaml, []string{"path", "size"}, "- path: repo/a.zip\n  size: 10\n- path: repo/b, c.zip\n  size: 20\n"},
+		{Table, []string{"path", "size"}, "PATH            SIZE\nrepo/a.zip      10\nrepo/b, c.zip   20\n"},
+	}
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			buffer, previousLog := redirectLogOutputToBuffer()
+			defer log.SetLogger(previousLog)
+			writer := NewOutputWriter(&OutputOptions{Format: test.format, Fields: test.fields})
+			for _, record := range outputRecordsMock {
+				assert.NoError(t, writer.Write(record))
+			}
+			assert.NoError(t, writer.Close())
+			assert.Equal(t, test.expected, buffer.String())
+		})
+	}
+}
+
+func TestOutputWriterNoRecords(t *testing.T) {
+	buffer, previousLog := redirectLogOutputToBuffer()
+	defer log.SetLogger(previousLog)
+	assert.NoError(t, NewOutputWriter(&OutputOptions{Format: Json}).Close())
+	assert.Equal(t, "[]\n", buffer.String())
+}
+
+func TestPrintOutputRecord(t *testing.T) {
+	buffer, previousLog := redirectLogOutputToBuffer()
+	defer log.SetLogger(previousLog)
+	assert.NoError(t, PrintOutputRecord(&OutputOptions{Format: Yaml}, outputRecordsMock[1]))
+	assert.Equal(t, "path: repo/b, c.zip\nsize: 20\n", buffer.String())
+}
+
+func TestParseOutputOptions(t *testing.T) {
+	format, err := parseOutputFormat("JSONL")
+	assert.NoError(t, err)
+	assert.Equal(t, JsonLines, format)
+	_, err = parseOutputFormat("xml")
+	assert.Error(t, err)
+
+	fields, err := parseOutputFields(" path, size,", []string{"path", "size", "sha1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"path", "size"}, fields)
+	_, err = parseOutputFields("path,sha256", []string{"path", "size", "sha1"})
+	assert.Error(t, err)
+	_, err = parseOutputFields(",", nil)
+	assert.Error(t, err)
+}
+
+func redirectLogOutputToBuffer() (*bytes.Buffer, log.Log) {
+	previousLog := log.Logger
+	newLog := log.NewLogger(log.GetLogLevel(), nil)
+	buffer := &bytes.Buffer{}
+	newLog.SetOutputWriter(buffer)
+	log.SetLogger(newLog)
+	return buffer, previousLog
+}
+
+func TestToTableHeaders(t *testing.T) {
+	assert.Equal(t, []string{"PATH", "SERVER ID", "ARTIFACTORY URL", "LATEST VERSION", "SHA1"},
+		toTableHeaders([]string{"path", "serverId", "artifactoryUrl", "latest_version", "sha1"}))
+}
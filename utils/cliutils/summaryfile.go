@@ -0,0 +1,287 @@
+package cliutils
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli/utils/redact"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The formats of the summary file, written using the --summary-file option.
+type SummaryFileFormat string
+
+const (
+	// One test case per affected file. Failures are reported as failed test cases.
+	JunitSummaryFile SummaryFileFormat = "junit"
+	// Appended to the file, so that it can be added to the job summary of the CI system, such as $GITHUB_STEP_SUMMARY.
+	MarkdownSummaryFile SummaryFileFormat = "markdown"
+)
+
+// The summary file requested using the --summary-file and --summary-format options.
+type SummaryFileOptions struct {
+	Path   string
+	Format SummaryFileFormat
+	// The command which the summary is written for, such as 'rt upload'.
+	CommandName string
+}
+
+// Returns the summary file options of the command, or nil if the --summary-file option isn't set.
+func GetSummaryFileOptions(c *cli.Context, commandName string) (*SummaryFileOptions, error) {
+	if c.String("summary-file") == "" {
+		if c.IsSet("summary-format") {
+			return nil, errorutils.CheckError(errors.New("the --summary-format option can be used only together with the --summary-file option"))
+		}
+		return nil, nil
+	}
+	options := &SummaryFileOptions{Path: c.String("summary-file"), Format: JunitSummaryFile, CommandName: commandName}
+	if c.IsSet("summary-format") {
+		switch format := SummaryFileFormat(strings.ToLower(c.String("summary-format"))); format {
+		case JunitSummaryFile, MarkdownSummaryFile:
+			options.Format = format
+		default:
+			return nil, errorutils.CheckError(fmt.Errorf("the --summary-format option accepts the following values: %s, %s. Got '%s'",
+				JunitSummaryFile, MarkdownSummaryFile, c.String("summary-format")))
+		}
+	}
+	return options, nil
+}
+
+// A file affected by the command, as written to the summary file.
+type summaryFileRecord struct {
+	Source string
+	Target string
+	Sha256 string
+	// Set by the commands which act on existing artifacts, such as move and delete.
+	Status string
+	Reason string
+}
+
+func (record *summaryFileRecord) isFailed() bool {
+	return record.Status == summary.StatusTypes[summary.Failure]
+}
+
+// Writes the summary of a transfer command to the summary file, if requested.
+// If a reader is provided, each transferred file is written to the summary. The reader is reset, so it can be read again.
+// A given non-nil error will pass through and be returned as is, like in PrintSummaryReport.
+func WriteSummaryFile(options *SummaryFileOptions, success, failed int, reader *content.ContentReader, originalErr error) error {
+	if options == nil {
+		return originalErr
+	}
+	var records []*summaryFileRecord
+	if reader != nil {
+		reader.Reset()
+		for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+			records = append(records, &summaryFileRecord{Source: transferDetails.SourcePath, Target: transferDetails.TargetPath, Sha256: transferDetails.Sha256})
+		}
+		mErr := reader.GetError()
+		reader.Reset()
+		if mErr != nil {
+			return summaryPrintError(mErr, originalErr)
+		}
+	}
+	return summaryPrintError(writeSummaryFile(options, summary.GetSummaryReport(success, failed, originalErr), records, originalErr), originalErr)
+}
+
+// Writes the summary of a command which acts on existing artifacts, such as move and delete, to the summary file, if requested.
+// If a reader of DetailedSummaryRecord is provided, each item is written to the summary, including its status and failure reason.
+// The reader is reset, so it can be read again.
+func WriteItemsSummaryFile(options *SummaryFileOptions, success, failed int, reader *content.ContentReader, originalErr error) error {
+	if options == nil {
+		return originalErr
+	}
+	var records []*summaryFileRecord
+	if reader != nil {
+		reader.Reset()
+		for record := new(DetailedSummaryRecord); reader.NextRecord(record) == nil; record = new(DetailedSummaryRecord) {
+			records = append(records, &summaryFileRecord{Source: record.Source, Target: record.Target, Status: record.Status, Reason: record.Reason})
+		}
+		mErr := reader.GetError()
+		reader.Reset()
+		if mErr != nil {
+			return summaryPrintError(mErr, originalErr)
+		}
+	}
+	return summaryPrintError(writeSummaryFile(options, summary.GetSummaryReport(success, failed, originalErr), records, originalErr), originalErr)
+}
+
+// Writes the summary of a build-info publishing to the summary file, if requested.
+// The published build-info is written as the single affected file.
+func WriteBuildInfoSummaryFile(options *SummaryFileOptions, buildName, buildNumber string, succeeded bool, sha256 string, originalErr error) error {
+	if options == nil {
+		return originalErr
+	}
+	success, failed := getBuildInfoSummaryTotals(succeeded)
+	var records []*summaryFileRecord
+	if succeeded {
+		records = append(records, &summaryFileRecord{Target: buildName + "/" + buildNumber, Sha256: sha256})
+	}
+	return summaryPrintError(writeSummaryFile(options, summary.GetSummaryReport(success, failed, originalErr), records, originalErr), originalErr)
+}
+
+func writeSummaryFile(options *SummaryFileOptions, summaryReport *summary.Summary, records []*summaryFileRecord, originalErr error) error {
+	log.Debug("Writing the " + string(options.Format) + " summary to " + options.Path)
+	// The failure reasons and the error may include secrets, such as the credentials of a URL.
+	for _, record := range records {
+		record.Reason = redact.String(record.Reason)
+	}
+	if originalErr != nil {
+		originalErr = errors.New(redact.String(originalErr.Error()))
+	}
+	if options.Format == MarkdownSummaryFile {
+		file, err := os.OpenFile(options.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		defer file.Close()
+		return errorutils.CheckError(writeMarkdownSummary(file, options.CommandName, summaryReport, records, originalErr))
+	}
+	file, err := os.Create(options.Path)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer file.Close()
+	return errorutils.CheckError(writeJunitSummary(file, options.CommandName, summaryReport, records, originalErr))
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+// Writes the summary as a JUnit XML report.
+// Each affected file is a test case, which fails if the file's status is failure.
+// Failures which aren't reported per file are reported as a single failed test case.
+func writeJunitSummary(writer io.Writer, commandName string, summaryReport *summary.Summary, records []*summaryFileRecord, originalErr error) error {
+	suite := junitTestSuite{Name: commandName}
+	failedRecords := 0
+	for _, record := range records {
+		var systemOut []string
+		if record.Source != "" {
+			systemOut = append(systemOut, "Source: "+record.Source)
+		}
+		if record.Sha256 != "" {
+			systemOut = append(systemOut, "Sha256: "+record.Sha256)
+		}
+		testCase := junitTestCase{ClassName: commandName, Name: record.Target, SystemOut: strings.Join(systemOut, "\n")}
+		if record.isFailed() {
+			testCase.Failure = &junitFailure{Message: record.Reason}
+			failedRecords++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suite.Failures = failedRecords
+	if summaryReport.Totals.Failure > failedRecords || (originalErr != nil && failedRecords == 0) {
+		failure := &junitFailure{Message: strconv.Itoa(summaryReport.Totals.Failure) + " failed"}
+		if originalErr != nil {
+			failure.Content = originalErr.Error()
+		}
+		suite.TestCases = append(suite.TestCases, junitTestCase{ClassName: commandName, Name: commandName + " failures", Failure: failure})
+		suite.Failures++
+	}
+	suite.Tests = len(suite.TestCases)
+	suites := junitTestSuites{Name: "JFrog CLI " + commandName, Tests: suite.Tests, Failures: suite.Failures, Suites: []junitTestSuite{suite}}
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, "\n")
+	return err
+}
+
+// Writes the summary as a Markdown section, including a table of the affected files.
+func writeMarkdownSummary(writer io.Writer, commandName string, summaryReport *summary.Summary, records []*summaryFileRecord, originalErr error) error {
+	var builder strings.Builder
+	builder.WriteString("### JFrog CLI " + commandName + " summary\n\n")
+	builder.WriteString("| Status | Succeeded | Failed |\n|---|---|---|\n")
+	builder.WriteString(fmt.Sprintf("| %s | %d | %d |\n", summary.StatusTypes[summaryReport.Status], summaryReport.Totals.Success, summaryReport.Totals.Failure))
+	if originalErr != nil {
+		builder.WriteString("\n**Error:** " + escapeMarkdown(originalErr.Error()) + "\n")
+	}
+	if len(records) > 0 {
+		builder.WriteString(fmt.Sprintf("\n<details>\n<summary>Files (%d)</summary>\n\n", len(records)))
+		columns := getMarkdownColumns(records)
+		builder.WriteString("| " + strings.Join(columns, " | ") + " |\n|" + strings.Repeat("---|", len(columns)) + "\n")
+		for _, record := range records {
+			values := []string{escapeMarkdown(record.Source), escapeMarkdown(record.Target)}
+			for _, column := range columns[2:] {
+				switch column {
+				case "Sha256":
+					values = append(values, record.Sha256)
+				case "Status":
+					values = append(values, record.Status)
+				case "Reason":
+					values = append(values, escapeMarkdown(record.Reason))
+				}
+			}
+			builder.WriteString("| " + strings.Join(values, " | ") + " |\n")
+		}
+		builder.WriteString("\n</details>\n")
+	}
+	builder.WriteString("\n")
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// Returns the columns of the files table. The Sha256, Status and Reason columns are added only if any of the files has them.
+func getMarkdownColumns(records []*summaryFileRecord) []string {
+	columns := []string{"Source", "Target"}
+	var hasSha256, hasStatus, hasReason bool
+	for _, record := range records {
+		hasSha256 = hasSha256 || record.Sha256 != ""
+		hasStatus = hasStatus || record.Status != ""
+		hasReason = hasReason || record.Reason != ""
+	}
+	if hasSha256 {
+		columns = append(columns, "Sha256")
+	}
+	if hasStatus {
+		columns = append(columns, "Status")
+	}
+	if hasReason {
+		columns = append(columns, "Reason")
+	}
+	return columns
+}
+
+// Escapes the characters which would break a Markdown table cell.
+func escapeMarkdown(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
@@ -0,0 +1,107 @@
+package cliutils
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var summaryFileRecordsMock = []*summaryFileRecord{
+	{Source: "a.zip", Target: "repo/a.zip", Sha256: "123"},
+	{Source: "b|c.zip", Target: "repo/b|c.zip"},
+}
+
+func TestWriteJunitSummary(t *testing.T) {
+	var buffer bytes.Buffer
+	err := writeJunitSummary(&buffer, "rt upload", summary.GetSummaryReport(2, 1, nil), summaryFileRecordsMock, errors.New("upload <failed>"))
+	assert.NoError(t, err)
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="JFrog CLI rt upload" tests="3" failures="1">
+  <testsuite name="rt upload" tests="3" failures="1">
+    <testcase classname="rt upload" name="repo/a.zip">
+      <system-out>Source: a.zip&#xA;Sha256: 123</system-out>
+    </testcase>
+    <testcase classname="rt upload" name="repo/b|c.zip">
+      <system-out>Source: b|c.zip</system-out>
+    </testcase>
+    <testcase classname="rt upload" name="rt upload failures">
+      <failure message="1 failed">upload &lt;failed&gt;</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	assert.Equal(t, expected, buffer.String())
+}
+
+var itemsSummaryFileRecordsMock = []*summaryFileRecord{
+	{Source: "repo/a.zip", Target: "other-repo/a.zip", Status: "success"},
+	{Source: "repo/b.zip", Target: "other-repo/b.zip", Status: "failure", Reason: "409 Conflict"},
+}
+
+func TestWriteJunitItemsSummary(t *testing.T) {
+	var buffer bytes.Buffer
+	err := writeJunitSummary(&buffer, "rt move", summary.GetSummaryReport(1, 1, nil), itemsSummaryFileRecordsMock, errors.New("move failed"))
+	assert.NoError(t, err)
+	// The failed item is reported as a failed test case, so no additional failures test case is expected.
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="JFrog CLI rt move" tests="2" failures="1">
+  <testsuite name="rt move" tests="2" failures="1">
+    <testcase classname="rt move" name="other-repo/a.zip">
+      <system-out>Source: repo/a.zip</system-out>
+    </testcase>
+    <testcase classname="rt move" name="other-repo/b.zip">
+      <failure message="409 Conflict"></failure>
+      <system-out>Source: repo/b.zip</system-out>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestWriteMarkdownItemsSummary(t *testing.T) {
+	var buffer bytes.Buffer
+	err := writeMarkdownSummary(&buffer, "rt move", summary.GetSummaryReport(1, 1, nil), itemsSummaryFileRecordsMock, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, buffer.String(), "| Source | Target | Status | Reason |\n|---|---|---|---|\n"+
+		"| repo/a.zip | other-repo/a.zip | success |  |\n| repo/b.zip | other-repo/b.zip | failure | 409 Conflict |\n")
+}
+
+func TestWriteMarkdownSummary(t *testing.T) {
+	var buffer bytes.Buffer
+	err := writeMarkdownSummary(&buffer, "rt upload", summary.GetSummaryReport(2, 0, nil), summaryFileRecordsMock, nil)
+	assert.NoError(t, err)
+	expected := "### JFrog CLI rt upload summary\n\n" +
+		"| Status | Succeeded | Failed |\n|---|---|---|\n| success | 2 | 0 |\n\n" +
+		"<details>\n<summary>Files (2)</summary>\n\n" +
+		"| Source | Target | Sha256 |\n|---|---|---|\n| a.zip | repo/a.zip | 123 |\n| b\\|c.zip | repo/b\\|c.zip |  |\n\n</details>\n\n"
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestWriteSummaryFile(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+
+	// Markdown summaries should be appended to the file.
+	options := &SummaryFileOptions{Path: filepath.Join(tmpDir, "summary.md"), Format: MarkdownSummaryFile, CommandName: "rt copy"}
+	originalErr := errors.New("copy failed")
+	assert.Equal(t, originalErr, WriteSummaryFile(options, 0, 1, nil, originalErr))
+	assert.NoError(t, WriteBuildInfoSummaryFile(options, "build", "1", true, "456", nil))
+	content, err := ioutil.ReadFile(options.Path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "### JFrog CLI rt copy summary")
+	assert.Contains(t, string(content), "**Error:** copy failed")
+	assert.Contains(t, string(content), "|  | build/1 | 456 |")
+
+	// A missing summary file should not be written.
+	assert.NoError(t, WriteSummaryFile(nil, 1, 0, nil, nil))
+}
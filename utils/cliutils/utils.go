@@ -8,6 +8,7 @@ import (
 	"github.com/codegangsta/cli"
 	"github.com/jfrog/jfrog-cli-core/utils/config"
 	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/redact"
 	"github.com/jfrog/jfrog-cli/utils/summary"
 	"github.com/jfrog/jfrog-client-go/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
@@ -57,7 +58,7 @@ func GetCliError(err error, success, failed int, failNoOp bool) error {
 		{
 			var errorMessage string
 			if err != nil {
-				errorMessage = err.Error()
+				errorMessage = redact.String(err.Error())
 			}
 			return coreutils.CliError{ExitCode: coreutils.ExitCodeError, ErrorMsg: errorMessage}
 		}
@@ -68,9 +69,47 @@ func GetCliError(err error, success, failed int, failNoOp bool) error {
 	}
 }
 
+// The details a command reports about its run, provided to the plugins hooks and recorded in the audit log after the command ends.
+type CommandReport struct {
+	// The command's result. Commands which create a summary report have their summary as their result.
+	Result interface{}
+	// The details of the server the command uses.
+	ServerDetails *config.ServerDetails
+}
+
+const commandReportKey = "commandReport"
+
+// Returns the report of the command run by the app, creating it if needed.
+// The report is kept in the app's metadata, which is shared with the apps of the subcommands,
+// so the command sets it through its context, and the app's runner reads it after the command ends.
+func GetCommandReport(app *cli.App) *CommandReport {
+	if app.Metadata == nil {
+		app.Metadata = map[string]interface{}{}
+	}
+	report, ok := app.Metadata[commandReportKey].(*CommandReport)
+	if !ok {
+		report = new(CommandReport)
+		app.Metadata[commandReportKey] = report
+	}
+	return report
+}
+
+// Sets the result of the command running in the context.
+func SetCommandResult(c *cli.Context, result interface{}) {
+	GetCommandReport(c.App).Result = result
+}
+
+// Sets the details of the server the command running in the context uses.
+func SetCommandServerDetails(c *cli.Context, details *config.ServerDetails) {
+	GetCommandReport(c.App).ServerDetails = details
+}
+
 type DetailedSummaryRecord struct {
 	Source string `json:"source,omitempty"`
 	Target string `json:"target"`
+	// The status and failure reason of the item, reported by the commands which act on existing artifacts, such as move and delete.
+	Status string `json:"status,omitempty"`
+	Reason string `json:"reason,omitempty"`
 }
 
 type ExtendedDetailedSummaryRecord struct {
@@ -91,7 +130,8 @@ func summaryPrintError(summaryError, originalError error) error {
 	return summaryError
 }
 
-func PrintSummaryReport(success, failed int, originalErr error) error {
+func PrintSummaryReport(c *cli.Context, success, failed int, originalErr error) error {
+	SetCommandResult(c, summary.GetSummaryReport(success, failed, originalErr))
 	basicSummary, mErr := CreateSummaryReportString(success, failed, originalErr)
 	if mErr != nil {
 		return summaryPrintError(mErr, originalErr)
@@ -102,8 +142,35 @@ func PrintSummaryReport(success, failed int, originalErr error) error {
 
 // Prints a summary report.
 // If a resultReader is provided, we will iterate over the result and print a detailed summary including the affected files.
-func PrintDetailedSummaryReport(success, failed int, reader *content.ContentReader, printExtendedDetails bool, originalErr error) error {
-	basicSummary, mErr := CreateSummaryReportString(success, failed, originalErr)
+func PrintDetailedSummaryReport(c *cli.Context, success, failed int, reader *content.ContentReader, printExtendedDetails bool, originalErr error) error {
+	return PrintTransferSummaryReport(c, success, failed, nil, reader, printExtendedDetails, originalErr)
+}
+
+// Prints the summary report of a command which transfers files, such as upload and download, including the provided transfer statistics.
+// If a resultReader is provided, we will iterate over the result and print a detailed summary including the affected files.
+func PrintTransferSummaryReport(c *cli.Context, success, failed int, transferStats *summary.TransferStats, reader *content.ContentReader, printExtendedDetails bool, originalErr error) error {
+	summaryReport := summary.GetTransferSummaryReport(success, failed, transferStats, originalErr)
+	return printDetailedSummaryReport(c, summaryReport, reader, originalErr, func(writer *content.ContentWriter) {
+		for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+			writer.Write(getDetailedSummaryRecord(transferDetails, printExtendedDetails))
+		}
+	})
+}
+
+// Prints a summary report of a command which acts on existing artifacts, such as move, copy, delete and set-props.
+// If a reader of DetailedSummaryRecord is provided, the records are printed as the affected files, including their status and failure reason.
+func PrintItemsSummaryReport(c *cli.Context, success, failed int, reader *content.ContentReader, originalErr error) error {
+	return printDetailedSummaryReport(c, summary.GetSummaryReport(success, failed, originalErr), reader, originalErr, func(writer *content.ContentWriter) {
+		for record := new(DetailedSummaryRecord); reader.NextRecord(record) == nil; record = new(DetailedSummaryRecord) {
+			record.Reason = redact.String(record.Reason)
+			writer.Write(*record)
+		}
+	})
+}
+
+func printDetailedSummaryReport(c *cli.Context, summaryReport *summary.Summary, reader *content.ContentReader, originalErr error, writeRecords func(writer *content.ContentWriter)) error {
+	SetCommandResult(c, summaryReport)
+	basicSummary, mErr := marshalSummaryReport(summaryReport)
 	if mErr != nil {
 		return summaryPrintError(mErr, originalErr)
 	}
@@ -128,9 +195,7 @@ func PrintDetailedSummaryReport(success, failed int, reader *content.ContentRead
 	if readerLength == 0 {
 		log.Output("  files: []")
 	} else {
-		for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
-			writer.Write(getDetailedSummaryRecord(transferDetails, printExtendedDetails))
-		}
+		writeRecords(writer)
 	}
 	mErr = writer.Close()
 	return summaryPrintError(mErr, originalErr)
@@ -153,11 +218,9 @@ func getDetailedSummaryRecord(transferDetails *clientutils.FileTransferDetails,
 	return record
 }
 
-func PrintBuildInfoSummaryReport(succeeded bool, sha256 string, originalErr error) error {
-	success, failed := 1, 0
-	if !succeeded {
-		success, failed = 0, 1
-	}
+func PrintBuildInfoSummaryReport(c *cli.Context, succeeded bool, sha256 string, originalErr error) error {
+	SetBuildInfoCommandResult(c, succeeded, sha256, originalErr)
+	success, failed := getBuildInfoSummaryTotals(succeeded)
 	summary, mErr := CreateBuildInfoSummaryReportString(success, failed, sha256, originalErr)
 	if mErr != nil {
 		return summaryPrintError(mErr, originalErr)
@@ -166,8 +229,24 @@ func PrintBuildInfoSummaryReport(succeeded bool, sha256 string, originalErr erro
 	return summaryPrintError(mErr, originalErr)
 }
 
+// Sets the build-info summary as the command's result, without printing it.
+func SetBuildInfoCommandResult(c *cli.Context, succeeded bool, sha256 string, err error) {
+	success, failed := getBuildInfoSummaryTotals(succeeded)
+	SetCommandResult(c, summary.NewBuildInfoSummary(success, failed, sha256, err))
+}
+
+func getBuildInfoSummaryTotals(succeeded bool) (success, failed int) {
+	if succeeded {
+		return 1, 0
+	}
+	return 0, 1
+}
+
 func CreateSummaryReportString(success, failed int, err error) (string, error) {
-	summaryReport := summary.GetSummaryReport(success, failed, err)
+	return marshalSummaryReport(summary.GetSummaryReport(success, failed, err))
+}
+
+func marshalSummaryReport(summaryReport *summary.Summary) (string, error) {
 	content, mErr := summaryReport.Marshal()
 	if errorutils.CheckError(mErr) != nil {
 		return "", mErr
@@ -240,6 +319,16 @@ func GetBuildUrl(buildUrl string) string {
 	return getOrDefaultEnv(buildUrl, BuildUrl)
 }
 
+// Registers the credentials of the server as secrets, to be masked in the logs and the error messages.
+func AddServerDetailsSecrets(details *config.ServerDetails) {
+	if details == nil {
+		return
+	}
+	for _, secret := range []string{details.Password, details.AccessToken, details.RefreshToken, details.ApiKey, details.SshPassphrase} {
+		redact.AddSecret(secret)
+	}
+}
+
 func GetEnvExclude(envExclude string) string {
 	return getOrDefaultEnv(envExclude, EnvExclude)
 }
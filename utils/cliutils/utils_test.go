@@ -1,8 +1,12 @@
 package cliutils
 
 import (
-	"github.com/stretchr/testify/assert"
+	"flag"
 	"testing"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestSplitAgentNameAndVersion(t *testing.T) {
@@ -24,3 +28,20 @@ func TestSplitAgentNameAndVersion(t *testing.T) {
 		assert.Equal(t, test.expectedAgentVersion, actualAgentVersion)
 	}
 }
+
+func TestPrintTransferSummaryReport(t *testing.T) {
+	c := cli.NewContext(cli.NewApp(), flag.NewFlagSet("upload", flag.ContinueOnError), nil)
+	transferStats := &summary.TransferStats{TotalBytes: 1024, ElapsedSeconds: 2, BytesPerSecond: 512}
+	assert.NoError(t, PrintTransferSummaryReport(c, 2, 0, transferStats, nil, true, nil))
+	report, ok := GetCommandReport(c.App).Result.(*summary.Summary)
+	if assert.True(t, ok) {
+		assert.Equal(t, &summary.Totals{Success: 2, TransferStats: transferStats}, report.Totals)
+	}
+
+	// The statistics are reported only by the command which provides them.
+	assert.NoError(t, PrintSummaryReport(c, 1, 0, nil))
+	report, ok = GetCommandReport(c.App).Result.(*summary.Summary)
+	if assert.True(t, ok) {
+		assert.Nil(t, report.Totals.TransferStats)
+	}
+}
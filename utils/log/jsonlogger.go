@@ -0,0 +1,106 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli/utils/redact"
+	clientLog "github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Matches the color escape sequences, which are removed from the messages.
+var colorEscapeRegexp = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// A logger which writes one JSON object per log line, with the secrets in the messages masked.
+// The command output, written using Output, isn't a log line and is therefore written as is.
+type jsonLogger struct {
+	logLevel     clientLog.LevelType
+	outputWriter io.Writer
+	logsWriter   io.Writer
+	// Synchronizes the writes, so that the lines of concurrent threads aren't mixed.
+	mutex sync.Mutex
+}
+
+// A single JSON log line.
+type jsonLogRecord struct {
+	Level     string `json:"level"`
+	Timestamp string `json:"timestamp"`
+	Command   string `json:"command,omitempty"`
+	Message   string `json:"message"`
+}
+
+func newJsonLogger(logLevel clientLog.LevelType, logToWriter io.Writer) *jsonLogger {
+	logger := new(jsonLogger)
+	logger.SetLogLevel(logLevel)
+	logger.SetOutputWriter(os.Stdout)
+	logger.SetLogsWriter(logToWriter)
+	return logger
+}
+
+func (logger *jsonLogger) GetLogLevel() clientLog.LevelType {
+	return logger.logLevel
+}
+
+func (logger *jsonLogger) SetLogLevel(logLevel clientLog.LevelType) {
+	logger.logLevel = logLevel
+}
+
+func (logger *jsonLogger) SetOutputWriter(writer io.Writer) {
+	logger.outputWriter = writer
+}
+
+// Sets the logs writer to Stderr unless an alternative one is provided.
+func (logger *jsonLogger) SetLogsWriter(writer io.Writer) {
+	if writer == nil {
+		writer = os.Stderr
+	}
+	logger.logsWriter = writer
+}
+
+func (logger *jsonLogger) Debug(a ...interface{}) {
+	logger.log(clientLog.DEBUG, "debug", a...)
+}
+
+func (logger *jsonLogger) Info(a ...interface{}) {
+	logger.log(clientLog.INFO, "info", a...)
+}
+
+func (logger *jsonLogger) Warn(a ...interface{}) {
+	logger.log(clientLog.WARN, "warn", a...)
+}
+
+func (logger *jsonLogger) Error(a ...interface{}) {
+	logger.log(clientLog.ERROR, "error", a...)
+}
+
+func (logger *jsonLogger) Output(a ...interface{}) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	fmt.Fprintln(logger.outputWriter, a...)
+}
+
+func (logger *jsonLogger) log(level clientLog.LevelType, levelName string, a ...interface{}) {
+	if logger.logLevel < level {
+		return
+	}
+	message := strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	logger.write(&jsonLogRecord{Level: levelName, Message: redact.String(colorEscapeRegexp.ReplaceAllString(message, ""))})
+}
+
+func (logger *jsonLogger) write(record *jsonLogRecord) {
+	record.Timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+	record.Command = commandName
+	content, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.logsWriter.Write(append(content, '\n'))
+}
@@ -1,16 +1,52 @@
 package log
 
 import (
-	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
-	"github.com/jfrog/jfrog-cli-core/utils/log"
-	"github.com/jfrog/jfrog-client-go/utils"
-	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	clientLog "github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Set to 'json' to log one JSON object per line, instead of the human readable log lines.
+	LogFormatEnv  = "JFROG_CLI_LOG_FORMAT"
+	JsonLogFormat = "json"
 )
 
+// The name of the running command, such as 'rt upload', included in the JSON log lines.
+var commandName string
+
+func SetCommandName(name string) {
+	commandName = name
+}
+
+func isJsonLogFormat() bool {
+	return strings.ToLower(os.Getenv(LogFormatEnv)) == JsonLogFormat
+}
+
+// Creates a new logger in the format determined by the JFROG_CLI_LOG_FORMAT environment variable.
+// The secrets in the log lines are masked.
+// If logToWriter != nil, logging is done to the provided writer instead of Stderr.
+func NewLogger(logLevel clientLog.LevelType, logToWriter io.Writer) clientLog.Log {
+	if isJsonLogFormat() {
+		return newJsonLogger(logLevel, logToWriter)
+	}
+	return newRedactingLogger(clientLog.NewLogger(logLevel, logToWriter))
+}
+
+// Sets the default logger, in the format determined by the JFROG_CLI_LOG_FORMAT environment variable.
+func SetDefaultLogger() {
+	clientLog.SetLogger(NewLogger(log.GetCliLogLevel(), nil))
+}
+
 func CreateLogFile() (*os.File, error) {
 	logDir, err := coreutils.CreateDirInJfrogHome(coreutils.JfrogLogsDirName)
 	if err != nil {
@@ -32,7 +68,7 @@ func CreateLogFile() (*os.File, error) {
 // Closes the log file and resets to the default logger
 func CloseLogFile(logFile *os.File) {
 	if logFile != nil {
-		log.SetDefaultLogger()
+		SetDefaultLogger()
 		err := logFile.Close()
 		utils.CheckErrorWithMessage(err, "failed closing the log file")
 	}
@@ -0,0 +1,30 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+
+	clientLog "github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A logger which collects the command output, written using Output, instead of writing it.
+// The log lines are written using the wrapped logger.
+type outputCapturingLogger struct {
+	clientLog.Log
+	output strings.Builder
+}
+
+func (logger *outputCapturingLogger) Output(a ...interface{}) {
+	fmt.Fprintln(&logger.output, a...)
+}
+
+// Runs the function and returns the command output it wrote, instead of writing it.
+// Some of the jfrog-client-go services, such as the Bintray show services, output their results rather than return them.
+// Capturing their output allows rendering the results in other formats.
+func CaptureOutput(run func() error) (string, error) {
+	logger := &outputCapturingLogger{Log: clientLog.Logger}
+	clientLog.SetLogger(logger)
+	defer clientLog.SetLogger(logger.Log)
+	err := run()
+	return logger.output.String(), err
+}
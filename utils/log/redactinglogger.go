@@ -0,0 +1,43 @@
+package log
+
+import (
+	"fmt"
+
+	"github.com/jfrog/jfrog-cli/utils/redact"
+	clientLog "github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A logger which masks the secrets in the log lines, before writing them using the wrapped logger.
+// The command output, written using Output, is written as is, since it may be the purpose of the command
+// to output a secret, as in 'jfrog config export'.
+type redactingLogger struct {
+	clientLog.Log
+}
+
+func newRedactingLogger(logger clientLog.Log) *redactingLogger {
+	return &redactingLogger{Log: logger}
+}
+
+func (logger *redactingLogger) Debug(a ...interface{}) {
+	logger.Log.Debug(redactArgs(a)...)
+}
+
+func (logger *redactingLogger) Info(a ...interface{}) {
+	logger.Log.Info(redactArgs(a)...)
+}
+
+func (logger *redactingLogger) Warn(a ...interface{}) {
+	logger.Log.Warn(redactArgs(a)...)
+}
+
+func (logger *redactingLogger) Error(a ...interface{}) {
+	logger.Log.Error(redactArgs(a)...)
+}
+
+func redactArgs(a []interface{}) []interface{} {
+	redacted := make([]interface{}, len(a))
+	for i, arg := range a {
+		redacted[i] = redact.String(fmt.Sprint(arg))
+	}
+	return redacted
+}
@@ -0,0 +1,323 @@
+package progressbar
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Set to 'log' or 'json' to report the progress as periodic log lines or newline-delimited JSON events,
+	// instead of the interactive progress bar. Useful in CI, where the progress bar isn't displayed.
+	ProgressEventsEnv = "JFROG_CLI_PROGRESS_EVENTS"
+	// A file to write the progress events to. The events are written to stderr by default.
+	ProgressEventsFileEnv = "JFROG_CLI_PROGRESS_EVENTS_FILE"
+	// The interval in seconds between the periodic progress reports.
+	ProgressEventsIntervalEnv = "JFROG_CLI_PROGRESS_EVENTS_INTERVAL"
+
+	defaultProgressEventsInterval = 10 * time.Second
+)
+
+// The formats of the progress events.
+type ProgressEventsFormat string
+
+const (
+	// A periodic human readable log line, summarizing the progress.
+	LogProgressEvents ProgressEventsFormat = "log"
+	// A JSON event per line, for each file started and finished, and periodically for the total progress.
+	JsonProgressEvents ProgressEventsFormat = "json"
+)
+
+// The event types written in the JSON format.
+const (
+	fileStartedEvent    = "fileStarted"
+	fileStateEvent      = "fileState"
+	fileFinishedEvent   = "fileFinished"
+	searchStartedEvent  = "searchStarted"
+	searchFinishedEvent = "searchFinished"
+	progressEvent       = "progress"
+	doneEvent           = "done"
+)
+
+// A non-interactive progress manager, which reports the progress of the transfers as events.
+type eventsProgressManager struct {
+	format ProgressEventsFormat
+	output io.Writer
+	// The events file, closed on Quit. Nil if the events are written to stderr.
+	outputFile *os.File
+	interval   time.Duration
+	// Synchronizes the writes to the output and the access to the progresses.
+	mutex         sync.Mutex
+	progresses    []*eventsProgress
+	stats         *transferStats
+	tasksCount    int64
+	filesFinished int64
+	stopReports   chan bool
+	reportsWg     sync.WaitGroup
+}
+
+// The progress of a single file transfer.
+type eventsProgress struct {
+	manager     *eventsProgressManager
+	Id          int
+	label       string
+	path        string
+	total       int64
+	transferred int64
+	removed     bool
+}
+
+// A progress event. The stats are included in the periodic and done events.
+type progressEventRecord struct {
+	Event string `json:"event"`
+	Time  string `json:"time"`
+	Id    int    `json:"id,omitempty"`
+	Label string `json:"label,omitempty"`
+	Path  string `json:"path,omitempty"`
+	Size  int64  `json:"size,omitempty"`
+	State string `json:"state,omitempty"`
+	// The bytes transferred of a finished file.
+	Bytes int64 `json:"bytes,omitempty"`
+	*progressStats
+}
+
+// The total progress of the command.
+type progressStats struct {
+	TotalTasks       int64 `json:"totalTasks"`
+	FilesStarted     int64 `json:"filesStarted"`
+	FilesFinished    int64 `json:"filesFinished"`
+	BytesTotal       int64 `json:"bytesTotal"`
+	BytesTransferred int64 `json:"bytesTransferred"`
+	ElapsedSeconds   int64 `json:"elapsedSeconds"`
+	// Bytes per second. The current smoothed rate in the periodic events, and the average rate in the done event.
+	Throughput int64 `json:"throughput"`
+	// The estimated seconds left. Omitted if it can't be estimated yet.
+	EtaSeconds *int64 `json:"etaSeconds,omitempty"`
+}
+
+// Initializes the progress events manager, if requested using the JFROG_CLI_PROGRESS_EVENTS environment variable.
+// Returns nil, nil if progress events weren't requested.
+func initProgressEventsIfRequested() (*eventsProgressManager, error) {
+	format := ProgressEventsFormat(strings.ToLower(os.Getenv(ProgressEventsEnv)))
+	switch format {
+	case "":
+		return nil, nil
+	case LogProgressEvents, JsonProgressEvents:
+	default:
+		return nil, errorutils.CheckError(fmt.Errorf("the %s environment variable accepts the following values: %s, %s. Got '%s'",
+			ProgressEventsEnv, LogProgressEvents, JsonProgressEvents, os.Getenv(ProgressEventsEnv)))
+	}
+	interval := defaultProgressEventsInterval
+	if intervalEnv := os.Getenv(ProgressEventsIntervalEnv); intervalEnv != "" {
+		seconds, err := strconv.Atoi(intervalEnv)
+		if err != nil || seconds <= 0 {
+			return nil, errorutils.CheckError(fmt.Errorf("the %s environment variable should be a positive number of seconds. Got '%s'", ProgressEventsIntervalEnv, intervalEnv))
+		}
+		interval = time.Duration(seconds) * time.Second
+	}
+	var output io.Writer = os.Stderr
+	var outputFile *os.File
+	if path := os.Getenv(ProgressEventsFileEnv); path != "" {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		output, outputFile = file, file
+	}
+	return newEventsProgressManager(format, output, interval, outputFile), nil
+}
+
+func newEventsProgressManager(format ProgressEventsFormat, output io.Writer, interval time.Duration, outputFile *os.File) *eventsProgressManager {
+	p := &eventsProgressManager{format: format, output: output, outputFile: outputFile, interval: interval, stats: newTransferStats(), stopReports: make(chan bool)}
+	if interval > 0 {
+		p.reportsWg.Add(1)
+		go p.reportPeriodically()
+	}
+	return p
+}
+
+func (p *eventsProgressManager) reportPeriodically() {
+	defer p.reportsWg.Done()
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.report(progressEvent)
+		case <-p.stopReports:
+			return
+		}
+	}
+}
+
+func (p *eventsProgressManager) NewProgressReader(total int64, label, path string) ioUtils.Progress {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	progress := &eventsProgress{manager: p, Id: len(p.progresses) + 1, label: label, path: shortenUrl(path), total: total}
+	p.progresses = append(p.progresses, progress)
+	p.stats.addTotal(total)
+	p.writeEvent(&progressEventRecord{Event: fileStartedEvent, Id: progress.Id, Label: label, Path: progress.path, Size: total})
+	return progress
+}
+
+func (p *eventsProgressManager) SetProgressState(id int, state string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	progress := p.progresses[id-1]
+	p.writeEvent(&progressEventRecord{Event: fileStateEvent, Id: id, Path: progress.path, State: state})
+}
+
+func (p *eventsProgressManager) GetProgress(id int) ioUtils.Progress {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.progresses[id-1]
+}
+
+func (p *eventsProgressManager) RemoveProgress(id int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	progress := p.progresses[id-1]
+	if progress.removed {
+		return
+	}
+	progress.removed = true
+	atomic.AddInt64(&p.filesFinished, 1)
+	p.writeEvent(&progressEventRecord{Event: fileFinishedEvent, Id: id, Path: progress.path, Bytes: atomic.LoadInt64(&progress.transferred)})
+}
+
+func (p *eventsProgressManager) IncGeneralProgressTotalBy(n int64) {
+	atomic.AddInt64(&p.tasksCount, n)
+}
+
+func (p *eventsProgressManager) GetTransferStats() *summary.TransferStats {
+	return p.stats.getSummary()
+}
+
+// Stops the periodic reports and writes the final progress.
+func (p *eventsProgressManager) Quit() {
+	close(p.stopReports)
+	p.reportsWg.Wait()
+	p.stats.stop()
+	p.report(doneEvent)
+	if p.outputFile != nil {
+		if err := p.outputFile.Close(); err != nil {
+			log.Error("Failed closing the progress events file: " + err.Error())
+		}
+	}
+}
+
+// Writes the total progress, as a periodic or a done event.
+func (p *eventsProgressManager) report(event string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	stats := p.getStats(event)
+	if p.format == LogProgressEvents {
+		p.writeLine(formatProgressLine(event, stats))
+		return
+	}
+	p.writeEvent(&progressEventRecord{Event: event, progressStats: stats})
+}
+
+// Returns the total progress. The done event includes the average throughput, instead of the current one.
+func (p *eventsProgressManager) getStats(event string) *progressStats {
+	stats := &progressStats{
+		TotalTasks:       atomic.LoadInt64(&p.tasksCount),
+		FilesStarted:     int64(len(p.progresses)),
+		FilesFinished:    atomic.LoadInt64(&p.filesFinished),
+		BytesTotal:       p.stats.getBytesTotal(),
+		BytesTransferred: p.stats.getBytesTransferred(),
+		ElapsedSeconds:   int64(p.stats.getElapsed().Seconds()),
+	}
+	if event == doneEvent {
+		stats.Throughput = p.stats.getSummary().BytesPerSecond
+		return stats
+	}
+	rate := p.stats.getRate(time.Now())
+	stats.Throughput = int64(rate)
+	stats.EtaSeconds = p.stats.estimateEta(rate, stats.TotalTasks, stats.FilesStarted)
+	return stats
+}
+
+func formatProgressLine(event string, stats *progressStats) string {
+	prefix := "[Progress]"
+	if event == doneEvent {
+		prefix = "[Progress] Done in " + (time.Duration(stats.ElapsedSeconds) * time.Second).String() + "."
+	}
+	line := fmt.Sprintf("%s Files: %d/%d finished", prefix, stats.FilesFinished, stats.FilesStarted)
+	// Commands which don't transfer bytes, such as move and delete, report only the files.
+	if stats.BytesTotal == 0 {
+		return line
+	}
+	line += fmt.Sprintf(", %s/%s transferred, %s/s", formatBytes(stats.BytesTransferred), formatBytes(stats.BytesTotal), formatBytes(stats.Throughput))
+	if stats.EtaSeconds != nil {
+		line += ", ETA " + formatEta(stats.EtaSeconds)
+	}
+	return line
+}
+
+// Writes a JSON event. Should be called while holding the mutex.
+func (p *eventsProgressManager) writeEvent(event *progressEventRecord) {
+	if p.format != JsonProgressEvents {
+		return
+	}
+	event.Time = time.Now().UTC().Format(time.RFC3339)
+	content, err := json.Marshal(event)
+	if err != nil {
+		log.Debug("Failed writing a progress event: " + err.Error())
+		return
+	}
+	p.writeLine(string(content))
+}
+
+func (p *eventsProgressManager) writeLine(line string) {
+	if p.output == nil {
+		return
+	}
+	if _, err := io.WriteString(p.output, line+"\n"); err != nil {
+		log.Debug("Failed writing a progress event: " + err.Error())
+	}
+}
+
+// Counts the bytes read from the reader.
+func (ep *eventsProgress) ActionWithProgress(reader io.Reader) io.Reader {
+	if reader == nil {
+		return nil
+	}
+	rc, ok := reader.(io.ReadCloser)
+	if !ok {
+		rc = ioutil.NopCloser(reader)
+	}
+	return &eventsProxyReader{ReadCloser: rc, progress: ep}
+}
+
+func (ep *eventsProgress) Abort() {}
+
+func (ep *eventsProgress) GetId() int {
+	return ep.Id
+}
+
+type eventsProxyReader struct {
+	io.ReadCloser
+	progress *eventsProgress
+}
+
+func (pr *eventsProxyReader) Read(p []byte) (n int, err error) {
+	n, err = pr.ReadCloser.Read(p)
+	if n > 0 {
+		atomic.AddInt64(&pr.progress.transferred, int64(n))
+		pr.progress.manager.stats.addTransferred(int64(n))
+	}
+	return
+}
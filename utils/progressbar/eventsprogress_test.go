@@ -0,0 +1,59 @@
+package progressbar
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonProgressEvents(t *testing.T) {
+	var output bytes.Buffer
+	progressMgr := newEventsProgressManager(JsonProgressEvents, &output, time.Hour, nil)
+	progressMgr.IncGeneralProgressTotalBy(2)
+	progress := progressMgr.NewProgressReader(4, "Uploading", "a/b.zip")
+	_, err := ioutil.ReadAll(progressMgr.GetProgress(progress.GetId()).ActionWithProgress(strings.NewReader("data")))
+	assert.NoError(t, err)
+	progressMgr.SetProgressState(progress.GetId(), "Merging")
+	progressMgr.RemoveProgress(progress.GetId())
+	progressMgr.Quit()
+
+	var events []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		var event map[string]interface{}
+		if assert.NoError(t, json.Unmarshal([]byte(line), &event), line) {
+			events = append(events, event)
+		}
+	}
+	if !assert.Len(t, events, 4) {
+		return
+	}
+	assert.Equal(t, "fileStarted", events[0]["event"])
+	assert.Equal(t, "a/b.zip", events[0]["path"])
+	assert.EqualValues(t, 4, events[0]["size"])
+	assert.Equal(t, "fileState", events[1]["event"])
+	assert.Equal(t, "Merging", events[1]["state"])
+	assert.Equal(t, "fileFinished", events[2]["event"])
+	assert.EqualValues(t, 4, events[2]["bytes"])
+	assert.Equal(t, "done", events[3]["event"])
+	assert.EqualValues(t, 2, events[3]["totalTasks"])
+	assert.EqualValues(t, 1, events[3]["filesFinished"])
+	assert.EqualValues(t, 4, events[3]["bytesTransferred"])
+	assert.NotContains(t, events[3], "etaSeconds")
+}
+
+func TestLogProgressEvents(t *testing.T) {
+	var output bytes.Buffer
+	progressMgr := newEventsProgressManager(LogProgressEvents, &output, time.Hour, nil)
+	progress := progressMgr.NewProgressReader(2048, "Downloading", "a/b.zip")
+	_, err := ioutil.ReadAll(progress.ActionWithProgress(bytes.NewReader(make([]byte, 2048))))
+	assert.NoError(t, err)
+	progressMgr.RemoveProgress(progress.GetId())
+	progressMgr.Quit()
+	// Only the final progress line is expected, since the interval didn't pass.
+	assert.Regexp(t, `^\[Progress\] Done in \S+\. Files: 1/1 finished, 2\.0 KiB/2\.0 KiB transferred, \S+ \S+/s\n$`, output.String())
+}
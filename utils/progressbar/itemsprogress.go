@@ -0,0 +1,94 @@
+package progressbar
+
+import (
+	"io"
+
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+)
+
+// Implemented by the progress managers of this package, to report the progress of the commands which act on existing artifacts,
+// such as move, copy and delete. These commands don't transfer bytes, so a spinner is displayed for each item instead of a bar.
+type ItemsProgressMgr interface {
+	ioUtils.ProgressMgr
+	// Displays the search for the items to act on, until the returned progress is aborted.
+	NewSearchProgress(pattern string) ioUtils.Progress
+	// Displays the action on a single item. The returned progress is removed using RemoveProgress, like the other progresses.
+	NewItemProgress(label, path string) ioUtils.Progress
+}
+
+// Displays the search for the items in the pattern, if supported by the progress manager.
+// Returns a function which ends the search progress. Does nothing if the progress manager is nil.
+func StartSearchProgress(progressMgr ioUtils.ProgressMgr, pattern string) (done func()) {
+	itemsProgressMgr, ok := progressMgr.(ItemsProgressMgr)
+	if !ok {
+		return func() {}
+	}
+	progress := itemsProgressMgr.NewSearchProgress(pattern)
+	return progress.Abort
+}
+
+// Displays the action on the item in the path, and counts it as done when the returned function is called.
+// Progress managers which don't display items display a progress with no size instead. Does nothing if the progress manager is nil.
+func StartItemProgress(progressMgr ioUtils.ProgressMgr, label, path string) (done func()) {
+	var progress ioUtils.Progress
+	switch mgr := progressMgr.(type) {
+	case nil:
+		return func() {}
+	case ItemsProgressMgr:
+		progress = mgr.NewItemProgress(label, path)
+	default:
+		progress = mgr.NewProgressReader(0, label, path)
+	}
+	return func() {
+		progressMgr.RemoveProgress(progress.GetId())
+	}
+}
+
+func (p *progressBarManager) NewSearchProgress(pattern string) ioUtils.Progress {
+	// The search spinner isn't one of the bars, since it isn't counted by the general progress bar.
+	p.barsRWMutex.Lock()
+	defer p.barsRWMutex.Unlock()
+	unit := &progressBarUnit{bar: p.addSpinner("  Searching  ", pattern), description: pattern}
+	return &SimpleProgressBar{progressBarUnit: unit}
+}
+
+func (p *progressBarManager) NewItemProgress(label, path string) ioUtils.Progress {
+	p.barsRWMutex.Lock()
+	defer p.barsRWMutex.Unlock()
+	p.barsWg.Add(1)
+	unit := &progressBarUnit{bar: p.addSpinner(label, path), description: path}
+	progress := &SimpleProgressBar{progressBarUnit: unit, Id: len(p.bars) + 1}
+	p.bars = append(p.bars, progress)
+	return progress
+}
+
+func (p *eventsProgressManager) NewSearchProgress(pattern string) ioUtils.Progress {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.writeEvent(&progressEventRecord{Event: searchStartedEvent, Path: pattern})
+	return &eventsSearchProgress{manager: p, pattern: pattern}
+}
+
+func (p *eventsProgressManager) NewItemProgress(label, path string) ioUtils.Progress {
+	return p.NewProgressReader(0, label, path)
+}
+
+// The search for the items to act on. Writes the searchFinished event when aborted.
+type eventsSearchProgress struct {
+	manager *eventsProgressManager
+	pattern string
+}
+
+func (sp *eventsSearchProgress) ActionWithProgress(reader io.Reader) io.Reader {
+	return reader
+}
+
+func (sp *eventsSearchProgress) Abort() {
+	sp.manager.mutex.Lock()
+	defer sp.manager.mutex.Unlock()
+	sp.manager.writeEvent(&progressEventRecord{Event: searchFinishedEvent, Path: sp.pattern})
+}
+
+func (sp *eventsSearchProgress) GetId() int {
+	return 0
+}
@@ -0,0 +1,59 @@
+package progressbar
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemsProgressEvents(t *testing.T) {
+	var output bytes.Buffer
+	progressMgr := newEventsProgressManager(JsonProgressEvents, &output, time.Hour, nil)
+	searchDone := StartSearchProgress(progressMgr, "repo/*")
+	searchDone()
+	progressMgr.IncGeneralProgressTotalBy(1)
+	itemDone := StartItemProgress(progressMgr, "Moving", "repo/a.zip")
+	itemDone()
+	progressMgr.Quit()
+
+	var events []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		var event map[string]interface{}
+		if assert.NoError(t, json.Unmarshal([]byte(line), &event), line) {
+			events = append(events, event)
+		}
+	}
+	if !assert.Len(t, events, 5) {
+		return
+	}
+	assert.Equal(t, "searchStarted", events[0]["event"])
+	assert.Equal(t, "repo/*", events[0]["path"])
+	assert.Equal(t, "searchFinished", events[1]["event"])
+	assert.Equal(t, "fileStarted", events[2]["event"])
+	assert.Equal(t, "Moving", events[2]["label"])
+	assert.Equal(t, "repo/a.zip", events[2]["path"])
+	assert.Equal(t, "fileFinished", events[3]["event"])
+	assert.Equal(t, "done", events[4]["event"])
+	assert.EqualValues(t, 1, events[4]["totalTasks"])
+	assert.EqualValues(t, 1, events[4]["filesFinished"])
+}
+
+func TestItemsProgressLog(t *testing.T) {
+	var output bytes.Buffer
+	progressMgr := newEventsProgressManager(LogProgressEvents, &output, time.Hour, nil)
+	StartSearchProgress(progressMgr, "repo/*")()
+	StartItemProgress(progressMgr, "Deleting", "repo/a.zip")()
+	progressMgr.Quit()
+	// No bytes are transferred, so only the files are reported.
+	assert.Regexp(t, `^\[Progress\] Done in \S+\. Files: 1/1 finished\n$`, output.String())
+}
+
+func TestItemsProgressWithoutProgressMgr(t *testing.T) {
+	// Should do nothing, since the progress isn't displayed.
+	StartSearchProgress(nil, "repo/*")()
+	StartItemProgress(nil, "Deleting", "repo/a.zip")()
+}
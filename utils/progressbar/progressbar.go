@@ -1,6 +1,7 @@
 package progressbar
 
 import (
+	"fmt"
 	"net/url"
 	"os"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
 	corelog "github.com/jfrog/jfrog-cli-core/utils/log"
 	logUtils "github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/jfrog/jfrog-cli/utils/summary"
 	"github.com/jfrog/jfrog-client-go/utils"
 	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
 	"github.com/jfrog/jfrog-client-go/utils/log"
@@ -25,6 +27,9 @@ const progressBarWidth = 20
 const minTerminalWidth = 70
 const progressRefreshRate = 200 * time.Millisecond
 
+// The approximate width of the tasks counters of the general progress bar.
+const generalBarCountersWidth = 16
+
 type progressBarManager struct {
 	// A list of progress bar objects.
 	bars []progressBar
@@ -42,12 +47,17 @@ type progressBarManager struct {
 	generalProgressBar *mpb.Bar
 	// A cumulative amount of tasks
 	tasksCount int64
+	// The amount of files started, and their total bytes.
+	filesStarted int64
+	stats        *transferStats
 }
 
 type progressBarUnit struct {
 	bar         *mpb.Bar
 	incrChannel chan int
 	description string
+	// The total bytes of all the bars. Nil for bars which don't transfer bytes.
+	stats *transferStats
 }
 
 type progressBar interface {
@@ -75,8 +85,11 @@ func (p *progressBarManager) NewProgressReader(total int64, label, path string)
 		),
 	)
 
+	atomic.AddInt64(&p.filesStarted, 1)
+	p.stats.addTotal(total)
+
 	// Add bar to bars array
-	unit := initNewBarUnit(newBar, path)
+	unit := initNewBarUnit(newBar, path, p.stats)
 	barId := len(p.bars) + 1
 	readerProgressBar := ReaderProgressBar{progressBarUnit: unit, Id: barId}
 	p.bars = append(p.bars, &readerProgressBar)
@@ -98,18 +111,23 @@ func (p *progressBarManager) addNewMergingSpinner(replacedBarId int) {
 	defer p.barsRWMutex.Unlock()
 	replacedBar := p.bars[replacedBarId-1].getProgressBarUnit()
 	p.bars[replacedBarId-1].Abort()
-	newBar := p.container.AddSpinner(1, mpb.SpinnerOnMiddle,
-		mpb.SpinnerStyle(createSpinnerFramesArray()),
-		mpb.AppendDecorators(
-			decor.Name(buildProgressDescription("  Merging  ", replacedBar.description, 0)),
-		),
-	)
+	newBar := p.addSpinner("  Merging  ", replacedBar.description)
 	// Bar replacement is a simple spinner and thus does not implement any read functionality
 	unit := &progressBarUnit{bar: newBar, description: replacedBar.description}
 	progressBar := SimpleProgressBar{progressBarUnit: unit, Id: replacedBarId}
 	p.bars[replacedBarId-1] = &progressBar
 }
 
+// Adds a spinner, which moves back and forth within the bar, until it is aborted.
+func (p *progressBarManager) addSpinner(label, path string) *mpb.Bar {
+	return p.container.AddSpinner(1, mpb.SpinnerOnMiddle,
+		mpb.SpinnerStyle(createSpinnerFramesArray()),
+		mpb.AppendDecorators(
+			decor.Name(buildProgressDescription(label, path, 0)),
+		),
+	)
+}
+
 func buildProgressDescription(label, path string, extraCharsLen int) string {
 	separator := " | "
 	// Max line length after decreasing bar width (*2 in case unicode chars with double width are used) and the extra chars
@@ -148,9 +166,9 @@ func buildDescByLimits(descMaxLength int, prefix, path, suffix string) string {
 	return prefix + path + suffix
 }
 
-func initNewBarUnit(bar *mpb.Bar, path string) *progressBarUnit {
+func initNewBarUnit(bar *mpb.Bar, path string, stats *transferStats) *progressBarUnit {
 	ch := make(chan int, 1000)
-	unit := &progressBarUnit{bar: bar, incrChannel: ch, description: path}
+	unit := &progressBarUnit{bar: bar, incrChannel: ch, description: path, stats: stats}
 	go incrBarFromChannel(unit)
 	return unit
 }
@@ -186,6 +204,7 @@ func (p *progressBarManager) RemoveProgress(id int) {
 }
 
 // Quits the progress bar while aborting the initial bars.
+// Prints the total size, elapsed time and average throughput of all the transfers.
 func (p *progressBarManager) Quit() {
 	if p.headlineBar != nil {
 		p.headlineBar.Abort(true)
@@ -201,6 +220,15 @@ func (p *progressBarManager) Quit() {
 	// Wait a refresh rate to make sure all aborts have finished
 	time.Sleep(progressRefreshRate)
 	p.container.Wait()
+	p.stats.stop()
+	// Commands which don't transfer bytes, such as move and delete, have no transfer statistics to print.
+	if p.stats.getBytesTransferred() > 0 {
+		fmt.Fprintln(os.Stderr, " "+p.stats.getSummaryLine())
+	}
+}
+
+func (p *progressBarManager) GetTransferStats() *summary.TransferStats {
+	return p.stats.getSummary()
 }
 
 func (p *progressBarManager) GetProgress(id int) ioUtils.Progress {
@@ -209,8 +237,17 @@ func (p *progressBarManager) GetProgress(id int) ioUtils.Progress {
 
 // Initializes progress bar if possible (all conditions in 'shouldInitProgressBar' are met).
 // Creates a log file and sets the Logger to it. Caller responsible to close the file.
+// If progress events are requested using the JFROG_CLI_PROGRESS_EVENTS environment variable, a non-interactive
+// progress manager which reports the progress as events is returned instead, without a log file.
 // Returns nil, nil, err if failed.
 func InitProgressBarIfPossible() (ioUtils.ProgressMgr, *os.File, error) {
+	eventsProgressMgr, err := initProgressEventsIfRequested()
+	if err != nil {
+		return nil, nil, err
+	}
+	if eventsProgressMgr != nil {
+		return eventsProgressMgr, nil, nil
+	}
 	shouldInit, err := shouldInitProgressBar()
 	if !shouldInit || err != nil {
 		return nil, nil, err
@@ -220,9 +257,9 @@ func InitProgressBarIfPossible() (ioUtils.ProgressMgr, *os.File, error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	log.SetLogger(log.NewLogger(corelog.GetCliLogLevel(), logFile))
+	log.SetLogger(logUtils.NewLogger(corelog.GetCliLogLevel(), logFile))
 
-	newProgressBar := &progressBarManager{}
+	newProgressBar := &progressBarManager{stats: newTransferStats()}
 	newProgressBar.barsWg = new(sync.WaitGroup)
 
 	// Initialize the progressBar container with wg, to create a single joint point
@@ -281,10 +318,39 @@ func (p *progressBarManager) newGeneralProgressBar() {
 		mpb.AppendDecorators(
 			decor.Name(" Tasks: "),
 			decor.CountersNoUnit("%d/%d"),
+			newTransferStatsDecorator(p),
 		),
 	)
 }
 
+// Displays the bytes transferred, the smoothed transfer rate and the ETA of all the transfers.
+type transferStatsDecorator struct {
+	decor.WC
+	manager *progressBarManager
+}
+
+func newTransferStatsDecorator(manager *progressBarManager) decor.Decorator {
+	d := &transferStatsDecorator{manager: manager}
+	d.Init()
+	return d
+}
+
+func (d *transferStatsDecorator) Decor(st *decor.Statistics) string {
+	stats := d.manager.stats
+	if stats.getBytesTotal() == 0 {
+		return d.FormatMsg("")
+	}
+	rate := stats.getRate(time.Now())
+	eta := stats.estimateEta(rate, atomic.LoadInt64(&d.manager.tasksCount), atomic.LoadInt64(&d.manager.filesStarted))
+	rateAndEta := fmt.Sprintf(" | %s/s | ETA %s", formatBytes(int64(rate)), formatEta(eta))
+	bytes := fmt.Sprintf(" | %s/%s", formatBytes(stats.getBytesTransferred()), formatBytes(stats.getBytesTotal()))
+	// Leave room for the bar and the tasks counters. The bytes are omitted if the terminal is too narrow.
+	if len(bytes+rateAndEta)+generalBarCountersWidth > terminalWidth-progressBarWidth*2 {
+		return d.FormatMsg(rateAndEta)
+	}
+	return d.FormatMsg(bytes + rateAndEta)
+}
+
 // Initializes a new progress bar for headline, with a spinner
 func (p *progressBarManager) newHeadlineBar(headline string) {
 	p.barsWg.Add(1)
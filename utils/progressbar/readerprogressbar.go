@@ -58,6 +58,9 @@ func (pr *proxyReader) Read(p []byte) (n int, err error) {
 	n, err = pr.ReadCloser.Read(p)
 	if n > 0 && (err == nil || err == io.EOF) {
 		pr.incrChannel(n)
+		if pr.unit.stats != nil {
+			pr.unit.stats.addTransferred(int64(n))
+		}
 	}
 	return
 }
@@ -0,0 +1,155 @@
+package progressbar
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+)
+
+const (
+	// The minimal interval between samples of the transfer rate.
+	rateSampleInterval = time.Second
+	// The weight of the latest sample in the smoothed transfer rate.
+	rateSmoothingFactor = 0.3
+)
+
+// Implemented by the progress managers of this package, which collect the transfer statistics.
+type TransferStatsCollector interface {
+	ioUtils.ProgressMgr
+	// Returns the statistics of all the transfers. Should be called after Quit.
+	GetTransferStats() *summary.TransferStats
+}
+
+// Returns a progress manager which only collects the transfer statistics, without reporting the progress.
+// Used when the progress isn't displayed, to include the statistics in the command summary.
+func NewTransferStatsCollector() TransferStatsCollector {
+	return newEventsProgressManager("", nil, 0, nil)
+}
+
+// The total bytes of all the transfers, used to calculate the throughput and the ETA.
+type transferStats struct {
+	startTime time.Time
+	// Set on Quit, to stop the elapsed time.
+	endTime time.Time
+	// The total size of the files started, and the bytes transferred so far.
+	bytesTotal       int64
+	bytesTransferred int64
+	// Synchronizes the rate sampling.
+	mutex           sync.Mutex
+	lastSampleTime  time.Time
+	lastSampleBytes int64
+	smoothedRate    float64
+}
+
+func newTransferStats() *transferStats {
+	now := time.Now()
+	return &transferStats{startTime: now, lastSampleTime: now}
+}
+
+func (ts *transferStats) addTotal(n int64) {
+	if n > 0 {
+		atomic.AddInt64(&ts.bytesTotal, n)
+	}
+}
+
+func (ts *transferStats) addTransferred(n int64) {
+	atomic.AddInt64(&ts.bytesTransferred, n)
+}
+
+func (ts *transferStats) getBytesTotal() int64 {
+	return atomic.LoadInt64(&ts.bytesTotal)
+}
+
+func (ts *transferStats) getBytesTransferred() int64 {
+	return atomic.LoadInt64(&ts.bytesTransferred)
+}
+
+// Returns the transfer rate in bytes per second, smoothed using an exponential moving average.
+// The rate is sampled at most once per rateSampleInterval, so it can be called on every refresh of the progress.
+func (ts *transferStats) getRate(now time.Time) float64 {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	elapsed := now.Sub(ts.lastSampleTime)
+	if elapsed < rateSampleInterval {
+		return ts.smoothedRate
+	}
+	transferred := ts.getBytesTransferred()
+	rate := float64(transferred-ts.lastSampleBytes) / elapsed.Seconds()
+	if ts.lastSampleTime.Equal(ts.startTime) {
+		ts.smoothedRate = rate
+	} else {
+		ts.smoothedRate = rateSmoothingFactor*rate + (1-rateSmoothingFactor)*ts.smoothedRate
+	}
+	ts.lastSampleTime, ts.lastSampleBytes = now, transferred
+	return ts.smoothedRate
+}
+
+// Estimates the seconds left in the provided rate. Returns nil if it can't be estimated yet.
+// The size of the pending tasks, which haven't started yet, is estimated using the average size of the files started.
+func (ts *transferStats) estimateEta(rate float64, tasksCount, filesStarted int64) *int64 {
+	transferred := ts.getBytesTransferred()
+	if transferred == 0 || rate <= 0 || filesStarted == 0 {
+		return nil
+	}
+	remainingBytes := ts.getBytesTotal() - transferred
+	if pendingTasks := tasksCount - filesStarted; pendingTasks > 0 {
+		remainingBytes += pendingTasks * ts.getBytesTotal() / filesStarted
+	}
+	eta := int64(math.Max(float64(remainingBytes), 0) / rate)
+	return &eta
+}
+
+func (ts *transferStats) stop() {
+	ts.endTime = time.Now()
+}
+
+func (ts *transferStats) getElapsed() time.Duration {
+	if ts.endTime.IsZero() {
+		return time.Since(ts.startTime)
+	}
+	return ts.endTime.Sub(ts.startTime)
+}
+
+// Returns the statistics of all the transfers, using the average throughput.
+func (ts *transferStats) getSummary() *summary.TransferStats {
+	elapsed := ts.getElapsed()
+	stats := &summary.TransferStats{TotalBytes: ts.getBytesTransferred(), ElapsedSeconds: math.Round(elapsed.Seconds()*1000) / 1000}
+	if elapsed > 0 {
+		stats.BytesPerSecond = int64(float64(stats.TotalBytes) / elapsed.Seconds())
+	}
+	return stats
+}
+
+// The final line, printed after all the transfers are done.
+func (ts *transferStats) getSummaryLine() string {
+	stats := ts.getSummary()
+	return fmt.Sprintf("Transferred %s in %s (%s/s).", formatBytes(stats.TotalBytes), ts.getElapsed().Round(time.Millisecond), formatBytes(stats.BytesPerSecond))
+}
+
+// Formats a bytes count using binary units, such as '1.5 MiB'.
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return strconv.FormatInt(bytes, 10) + " B"
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// Formats the ETA, such as '1m30s'.
+func formatEta(eta *int64) string {
+	if eta == nil {
+		return "-"
+	}
+	return (time.Duration(*eta) * time.Second).String()
+}
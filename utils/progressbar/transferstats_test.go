@@ -0,0 +1,57 @@
+package progressbar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferStatsRate(t *testing.T) {
+	stats := newTransferStats()
+	stats.addTotal(1000)
+	stats.addTransferred(100)
+	// The rate isn't sampled before the sample interval passes.
+	assert.Zero(t, stats.getRate(stats.startTime.Add(rateSampleInterval/2)))
+	// The first sample is taken as is.
+	assert.Equal(t, float64(100), stats.getRate(stats.startTime.Add(time.Second)))
+	// The next samples are smoothed.
+	stats.addTransferred(200)
+	assert.InDelta(t, rateSmoothingFactor*200+(1-rateSmoothingFactor)*100, stats.getRate(stats.startTime.Add(2*time.Second)), 0.001)
+}
+
+func TestTransferStatsEstimateEta(t *testing.T) {
+	stats := newTransferStats()
+	stats.addTotal(100)
+	// Nothing transferred yet.
+	assert.Nil(t, stats.estimateEta(10, 1, 1))
+	// Half of the single file was transferred.
+	stats.addTransferred(50)
+	eta := stats.estimateEta(10, 1, 1)
+	if assert.NotNil(t, eta) {
+		assert.EqualValues(t, 5, *eta)
+	}
+	// The pending task is estimated by the average size of the started files.
+	eta = stats.estimateEta(10, 2, 1)
+	if assert.NotNil(t, eta) {
+		assert.EqualValues(t, 15, *eta)
+	}
+}
+
+func TestTransferStatsSummary(t *testing.T) {
+	stats := newTransferStats()
+	stats.addTransferred(2048)
+	stats.startTime = stats.startTime.Add(-2 * time.Second)
+	stats.stop()
+	summary := stats.getSummary()
+	assert.EqualValues(t, 2048, summary.TotalBytes)
+	assert.InDelta(t, 2, summary.ElapsedSeconds, 0.1)
+	assert.InDelta(t, 1024, summary.BytesPerSecond, 50)
+	assert.Regexp(t, `^Transferred 2\.0 KiB in 2(\.\d+)?s \(\S+ \S+/s\)\.$`, stats.getSummaryLine())
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "100 B", formatBytes(100))
+	assert.Equal(t, "1.5 KiB", formatBytes(1536))
+	assert.Equal(t, "2.0 MiB", formatBytes(2*1024*1024))
+}
@@ -0,0 +1,226 @@
+// Package redact masks secrets in the text written by the CLI, such as the logs, the error messages,
+// the summaries and the environment variables collected into the build-info.
+// Secrets are identified by their shape, such as access tokens and private keys, by the names of the
+// environment variables, options, headers and query params which hold them, and by their values,
+// which are registered when they are read from the options, the environment or the config.
+package redact
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// Replaces the secrets.
+	Mask = "***"
+	// Semicolon separated, case insensitive patterns of the names of the environment variables whose values are secrets.
+	PatternsEnv     = "JFROG_CLI_REDACT_PATTERNS"
+	DefaultPatterns = "*password*;*psw*;*secret*;*apikey*;*api_key*;*private_key*;*token*;*passphrase*;*credential*"
+	// Shorter values aren't registered as secrets, since masking them would mask unrelated text.
+	minSecretLength = 6
+)
+
+// The parts of the option, query param and header names which hold credentials, in lower case.
+var sensitiveNameParts = []string{"authorization", "token", "password", "passwd", "passphrase", "secret", "apikey", "api_key", "api-key", "signature", "credential", "cookie"}
+
+// A secret shape, and the replacement of its matches.
+type secretShape struct {
+	regexp      *regexp.Regexp
+	replacement string
+}
+
+var secretShapes = []secretShape{
+	// Private key blocks, such as RSA, EC, OpenSSH and PGP private keys.
+	{regexp.MustCompile(`-----BEGIN ([A-Z0-9 ]*)PRIVATE KEY( BLOCK)?-----[\s\S]*?-----END [A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----`), "-----BEGIN ${1}PRIVATE KEY${2}-----" + Mask + "-----END ${1}PRIVATE KEY${2}-----"},
+	// JFrog access tokens, which are JWTs.
+	{regexp.MustCompile(`eyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`), Mask},
+	// JFrog reference tokens, which start with the base64 encoding of 'reftkn:01:'.
+	{regexp.MustCompile(`cmVmdGtuOjAxOj[A-Za-z0-9+/=]{20,}`), Mask},
+	// JFrog API keys.
+	{regexp.MustCompile(`\bAKCp[A-Za-z0-9]{20,}`), Mask},
+	// The password of URLs with basic authentication.
+	{regexp.MustCompile(`\b([a-zA-Z][a-zA-Z0-9+.-]*://[^:/@\s]+:)[^@/\s]+@`), "${1}" + Mask + "@"},
+	// AWS access key IDs.
+	{regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`), Mask},
+	// AWS secret access keys, which have no distinct shape, when assigned to their variable.
+	{regexp.MustCompile(`(?i)(aws_secret_access_key["']?\s*[=:]\s*["']?)[A-Za-z0-9/+=]{40}`), "${1}" + Mask},
+}
+
+var (
+	// The secret values registered by AddSecret, sorted from longest to shortest,
+	// so that a secret which contains another secret is masked as a whole.
+	secrets      []string
+	secretsMutex sync.RWMutex
+)
+
+// Registers a secret value, such as a password read from the config, to be masked wherever it appears.
+// Values shorter than 6 characters are ignored.
+func AddSecret(secret string) {
+	if len(secret) < minSecretLength {
+		return
+	}
+	secretsMutex.Lock()
+	defer secretsMutex.Unlock()
+	for _, existing := range secrets {
+		if existing == secret {
+			return
+		}
+	}
+	secrets = append(secrets, secret)
+	sort.SliceStable(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+}
+
+// Registers the values of the environment variables whose names match the redaction patterns as secrets.
+func AddEnvSecrets() {
+	patterns := GetPatterns()
+	for _, env := range os.Environ() {
+		pair := strings.SplitN(env, "=", 2)
+		if len(pair) == 2 && MatchesPatterns(pair[0], patterns) {
+			AddSecret(pair[1])
+		}
+	}
+}
+
+// Registers the values of the options which hold credentials, such as --access-token and --password, as secrets.
+// Both the --name=value and the --name value forms are supported.
+func AddArgsSecrets(args []string) {
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		value, hasValue := "", false
+		if nameValue := strings.SplitN(name, "=", 2); len(nameValue) == 2 {
+			name, value, hasValue = nameValue[0], nameValue[1], true
+		} else if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			value, hasValue = args[i+1], true
+		}
+		if hasValue && IsSecretOption(name) {
+			AddSecret(value)
+		}
+	}
+}
+
+// Returns a copy of the args, with the values of the options which hold credentials masked,
+// and the secrets in the other args masked as well.
+func Args(args []string) []string {
+	redacted := make([]string, len(args))
+	maskNext := false
+	for i, arg := range args {
+		switch {
+		case maskNext && !strings.HasPrefix(arg, "-"):
+			redacted[i] = Mask
+		case strings.HasPrefix(arg, "-") && IsSecretOption(strings.SplitN(strings.TrimLeft(arg, "-"), "=", 2)[0]):
+			if nameValue := strings.SplitN(arg, "=", 2); len(nameValue) == 2 {
+				redacted[i] = nameValue[0] + "=" + Mask
+			} else {
+				redacted[i] = arg
+				maskNext = true
+				continue
+			}
+		default:
+			redacted[i] = String(arg)
+		}
+		maskNext = false
+	}
+	return redacted
+}
+
+// Returns the redaction patterns, set by the JFROG_CLI_REDACT_PATTERNS environment variable.
+func GetPatterns() []string {
+	patterns := os.Getenv(PatternsEnv)
+	if patterns == "" {
+		patterns = DefaultPatterns
+	}
+	return strings.Split(patterns, ";")
+}
+
+// Returns true if the name matches any of the case insensitive patterns, such as '*token*'.
+func MatchesPatterns(name string, patterns []string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range patterns {
+		if matched, err := filepath.Match(strings.ToLower(strings.TrimSpace(pattern)), name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the option, query param or header name indicates that its value is a credential.
+func IsSensitiveName(name string) bool {
+	name = strings.ToLower(name)
+	for _, part := range sensitiveNameParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the option holds a credential. The --key option of the Bintray commands holds the API key as well.
+func IsSecretOption(name string) bool {
+	return IsSensitiveName(name) || strings.ToLower(name) == "key"
+}
+
+// Masks the registered secrets and the secret shapes in the text.
+func String(text string) string {
+	if text == "" {
+		return text
+	}
+	secretsMutex.RLock()
+	for _, secret := range secrets {
+		text = strings.ReplaceAll(text, secret, Mask)
+	}
+	secretsMutex.RUnlock()
+	for _, shape := range secretShapes {
+		text = shape.regexp.ReplaceAllString(text, shape.replacement)
+	}
+	return text
+}
+
+// Returns the value of the environment variable, masked entirely if its name matches the patterns.
+// Otherwise, the secrets in the value are masked.
+func EnvValue(name, value string, patterns []string) string {
+	if MatchesPatterns(name, patterns) {
+		return Mask
+	}
+	return String(value)
+}
+
+// Masks the password of the URL and the values of the query params which hold credentials.
+func Url(rawUrl string) string {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		// Drop the query, since the credentials in it can't be identified.
+		return String(strings.SplitN(rawUrl, "?", 2)[0])
+	}
+	hasPassword := false
+	if parsedUrl.User != nil {
+		if _, hasPassword = parsedUrl.User.Password(); hasPassword {
+			parsedUrl.User = url.UserPassword(parsedUrl.User.Username(), Mask)
+		}
+	}
+	if parsedUrl.RawQuery != "" {
+		params := strings.Split(parsedUrl.RawQuery, "&")
+		for i, param := range params {
+			key := strings.SplitN(param, "=", 2)[0]
+			if unescapedKey, err := url.QueryUnescape(key); err == nil && IsSensitiveName(unescapedKey) {
+				params[i] = key + "=" + Mask
+			}
+		}
+		parsedUrl.RawQuery = strings.Join(params, "&")
+	}
+	redactedUrl := parsedUrl.String()
+	if hasPassword {
+		// The mask is escaped when the URL is built.
+		redactedUrl = strings.Replace(redactedUrl, ":"+url.PathEscape(Mask)+"@", ":"+Mask+"@", 1)
+	}
+	return String(redactedUrl)
+}
@@ -71,6 +71,15 @@ type Summary struct {
 type Totals struct {
 	Success int `json:"success"`
 	Failure int `json:"failure"`
+	// Included in the summary of the commands which transfer files, such as upload and download.
+	*TransferStats
+}
+
+// The statistics of the files transferred by the command.
+type TransferStats struct {
+	TotalBytes     int64   `json:"totalBytes"`
+	ElapsedSeconds float64 `json:"elapsedSeconds"`
+	BytesPerSecond int64   `json:"bytesPerSecond"`
 }
 
 type BuildInfoSummary struct {
@@ -96,3 +105,10 @@ func GetSummaryReport(success, failed int, err error) *Summary {
 	}
 	return summaryReport
 }
+
+// Returns the summary report of a command which transfers files, such as upload and download, including its transfer statistics.
+func GetTransferSummaryReport(success, failed int, transferStats *TransferStats, err error) *Summary {
+	summaryReport := GetSummaryReport(success, failed, err)
+	summaryReport.Totals.TransferStats = transferStats
+	return summaryReport
+}
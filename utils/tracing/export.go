@@ -0,0 +1,182 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const exportTimeout = 10 * time.Second
+
+// The OTLP/HTTP JSON request, as defined by the ExportTraceServiceRequest message of OTLP.
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceId           string     `json:"traceId"`
+	SpanId            string     `json:"spanId"`
+	ParentSpanId      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            status     `json:"status"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+// Only one of the values is set. Integers are encoded as strings, like the JSON encoding of protobuf int64 fields.
+type anyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+// Sends the spans to the collector in a single request.
+func export(endpoint, traceId string, spans []*Span) error {
+	content, err := json.Marshal(newExportRequest(traceId, spans))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(content))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range parseHeaders(os.Getenv(HeadersEnv)) {
+		req.Header.Set(name, value)
+	}
+	client := &http.Client{Timeout: exportTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return errorutils.CheckError(errors.New("the OpenTelemetry collector responded with: " + resp.Status + " " + string(body)))
+	}
+	return nil
+}
+
+func newExportRequest(traceId string, spans []*Span) *exportRequest {
+	serviceName := os.Getenv(ServiceNameEnv)
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	resourceAttributes := toKeyValues(map[string]interface{}{
+		"service.name":    serviceName,
+		"service.version": cliutils.GetVersion(),
+	})
+	otlpSpans := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		otlpSpans = append(otlpSpans, span.toOtlp(traceId))
+	}
+	return &exportRequest{ResourceSpans: []resourceSpans{{
+		Resource:   resource{Attributes: resourceAttributes},
+		ScopeSpans: []scopeSpans{{Scope: scope{Name: defaultServiceName, Version: cliutils.GetVersion()}, Spans: otlpSpans}},
+	}}}
+}
+
+func (span *Span) toOtlp(traceId string) otlpSpan {
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+	return otlpSpan{
+		TraceId:           traceId,
+		SpanId:            span.spanId,
+		ParentSpanId:      span.parentSpanId,
+		Name:              span.name,
+		Kind:              span.kind,
+		StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+		Attributes:        toKeyValues(span.attributes),
+		Status:            status{Code: span.statusCode, Message: span.statusMsg},
+	}
+}
+
+// Returns the attributes sorted by their keys.
+func toKeyValues(attributes map[string]interface{}) []keyValue {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var keyValues []keyValue
+	for _, key := range keys {
+		var attributeValue anyValue
+		switch v := attributes[key].(type) {
+		case string:
+			attributeValue.StringValue = &v
+		case bool:
+			attributeValue.BoolValue = &v
+		case int:
+			str := strconv.Itoa(v)
+			attributeValue.IntValue = &str
+		case int64:
+			str := strconv.FormatInt(v, 10)
+			attributeValue.IntValue = &str
+		default:
+			continue
+		}
+		keyValues = append(keyValues, keyValue{Key: key, Value: attributeValue})
+	}
+	return keyValues
+}
+
+// Parses the headers in the format of the OTEL_EXPORTER_OTLP_HEADERS environment variable, such as 'api-key=abc,tenant=acme'.
+// The values may be URL encoded.
+func parseHeaders(headers string) map[string]string {
+	parsed := map[string]string{}
+	for _, header := range strings.Split(headers, ",") {
+		keyValue := strings.SplitN(header, "=", 2)
+		if len(keyValue) != 2 || strings.TrimSpace(keyValue[0]) == "" {
+			continue
+		}
+		value := strings.TrimSpace(keyValue[1])
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+		parsed[strings.TrimSpace(keyValue[0])] = value
+	}
+	return parsed
+}
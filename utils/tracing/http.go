@@ -0,0 +1,33 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/jfrog/jfrog-cli/utils/redact"
+)
+
+// Sends an HTTP request using the send function, and records a span of the request, if tracing is enabled.
+// The credentials in the URL are redacted.
+// Only the requests sent directly by the CLI are recorded this way. The HTTP client of jfrog-client-go builds its own
+// transport and doesn't accept a wrapping one, so the requests sent by its services aren't recorded.
+func TraceHttpRequest(method, rawUrl string, send func() (*http.Response, []byte, error)) (*http.Response, []byte, error) {
+	if tracer == nil {
+		return send()
+	}
+	span := startChildSpan("HTTP "+method, spanKindClient)
+	resp, body, err := send()
+	span.SetAttribute("http.method", method)
+	span.SetAttribute("http.url", redact.Url(rawUrl))
+	if resp != nil {
+		span.SetAttribute("http.status_code", resp.StatusCode)
+		if body == nil && resp.ContentLength > 0 {
+			span.SetAttribute("http.response_content_length", resp.ContentLength)
+		}
+	}
+	if body != nil {
+		span.SetAttribute("http.response_content_length", len(body))
+	}
+	span.SetError(err)
+	span.End()
+	return resp, body, err
+}
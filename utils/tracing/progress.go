@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jfrog/jfrog-cli/utils/redact"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+)
+
+// A progress manager which records a span for each file transfer, while delegating the progress reporting to the wrapped manager.
+type transferProgressMgr struct {
+	ioUtils.ProgressMgr
+	// The progress of the running transfers, by their IDs.
+	transfers sync.Map
+}
+
+// The progress of a single file transfer, which counts the transferred bytes.
+type transferProgress struct {
+	ioUtils.Progress
+	span             *Span
+	bytesTransferred int64
+}
+
+type countingReader struct {
+	reader   io.Reader
+	progress *transferProgress
+}
+
+// Returns a progress manager which records a span for each file transfer, in addition to reporting the progress using progressMgr.
+// Returns progressMgr itself if tracing isn't enabled.
+func WrapProgressMgr(progressMgr ioUtils.ProgressMgr) ioUtils.ProgressMgr {
+	if tracer == nil || progressMgr == nil {
+		return progressMgr
+	}
+	return &transferProgressMgr{ProgressMgr: progressMgr}
+}
+
+func (tpm *transferProgressMgr) NewProgressReader(total int64, label, path string) ioUtils.Progress {
+	progress := &transferProgress{Progress: tpm.ProgressMgr.NewProgressReader(total, label, path), span: StartSpan("file transfer")}
+	progress.span.SetAttribute("jfrog.transfer.action", label)
+	progress.span.SetAttribute("jfrog.path", redact.String(trimUrlParams(path)))
+	progress.span.SetAttribute("jfrog.bytes_total", total)
+	tpm.transfers.Store(progress.GetId(), progress)
+	return progress
+}
+
+func (tpm *transferProgressMgr) GetProgress(id int) ioUtils.Progress {
+	if progress, ok := tpm.transfers.Load(id); ok {
+		return progress.(*transferProgress)
+	}
+	return tpm.ProgressMgr.GetProgress(id)
+}
+
+func (tpm *transferProgressMgr) RemoveProgress(id int) {
+	tpm.ProgressMgr.RemoveProgress(id)
+	if progress, ok := tpm.transfers.Load(id); ok {
+		tpm.transfers.Delete(id)
+		progress := progress.(*transferProgress)
+		progress.span.SetAttribute("jfrog.bytes_transferred", atomic.LoadInt64(&progress.bytesTransferred))
+		progress.span.End()
+	}
+}
+
+func (tp *transferProgress) ActionWithProgress(reader io.Reader) io.Reader {
+	return tp.Progress.ActionWithProgress(&countingReader{reader: reader, progress: tp})
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.reader.Read(p)
+	atomic.AddInt64(&cr.progress.bytesTransferred, int64(n))
+	return n, err
+}
+
+// Removes the matrix params and the query of the upload URLs, which may hold property values.
+func trimUrlParams(path string) string {
+	if i := strings.IndexAny(path, ";?"); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
@@ -0,0 +1,197 @@
+// Package tracing records the spans of the running command and exports them to an OpenTelemetry collector,
+// using the OTLP/HTTP protocol with the JSON encoding.
+// Tracing is enabled only if the OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment
+// variable is set. Otherwise, all the functions of this package do nothing, and the returned spans are nil.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli/utils/redact"
+)
+
+const (
+	// The base URL of the collector. The traces are sent to its /v1/traces path.
+	EndpointEnv = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	// The full URL the traces are sent to. Takes precedence over OTEL_EXPORTER_OTLP_ENDPOINT.
+	TracesEndpointEnv = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	// Comma separated key=value pairs, sent as the headers of the export request.
+	HeadersEnv     = "OTEL_EXPORTER_OTLP_HEADERS"
+	ServiceNameEnv = "OTEL_SERVICE_NAME"
+	// The W3C trace context of the caller, such as a CI stage. If set, the command span is created as its child.
+	TraceParentEnv = "TRACEPARENT"
+
+	defaultServiceName = "jfrog-cli"
+	tracesPath         = "/v1/traces"
+)
+
+// The span kinds, as defined by OTLP.
+const (
+	spanKindInternal = 1
+	spanKindClient   = 3
+)
+
+// The status code of a failed span, as defined by OTLP.
+const statusCodeError = 2
+
+// The tracer of the running command. Nil if tracing isn't enabled.
+var tracer *commandTracer
+
+type commandTracer struct {
+	endpoint string
+	traceId  string
+	root     *Span
+	// The spans which ended, to be exported when the command ends.
+	ended []*Span
+	mutex sync.Mutex
+}
+
+// A single operation of the command, such as a search or a file transfer.
+// The methods of a nil span do nothing, so that the callers don't need to check whether tracing is enabled.
+type Span struct {
+	name         string
+	kind         int
+	spanId       string
+	parentSpanId string
+	start        time.Time
+	end          time.Time
+	attributes   map[string]interface{}
+	statusCode   int
+	statusMsg    string
+	mutex        sync.Mutex
+}
+
+func Enabled() bool {
+	return tracer != nil
+}
+
+// Starts the root span of the command, if tracing is enabled.
+// The span is ended and all the spans are exported by EndCommand.
+func StartCommand(commandName string) {
+	endpoint := getTracesEndpoint()
+	if endpoint == "" {
+		return
+	}
+	if commandName == "" {
+		commandName = "jfrog"
+	}
+	traceId, parentSpanId := parseTraceParent(os.Getenv(TraceParentEnv))
+	if traceId == "" {
+		traceId = newId(16)
+	}
+	tracer = &commandTracer{endpoint: endpoint, traceId: traceId}
+	tracer.root = newSpan(commandName, spanKindInternal, parentSpanId)
+	tracer.root.SetAttribute("jfrog.command", commandName)
+}
+
+// Sets an attribute on the root span of the command, such as the server ID.
+func SetCommandAttribute(key string, value interface{}) {
+	if tracer == nil {
+		return
+	}
+	tracer.root.SetAttribute(key, value)
+}
+
+// Ends the root span of the command with the exit code of the command, and exports all the spans.
+// A failure to export the spans doesn't fail the command, and is returned to be logged only.
+func EndCommand(exitCode int, err error) error {
+	if tracer == nil {
+		return nil
+	}
+	root := tracer.root
+	root.SetAttribute("process.exit_code", exitCode)
+	root.SetError(err)
+	root.End()
+	defer func() { tracer = nil }()
+	return export(tracer.endpoint, tracer.traceId, tracer.ended)
+}
+
+// Starts a span as a child of the command span. Returns nil if tracing isn't enabled.
+func StartSpan(name string) *Span {
+	return startChildSpan(name, spanKindInternal)
+}
+
+func startChildSpan(name string, kind int) *Span {
+	if tracer == nil {
+		return nil
+	}
+	return newSpan(name, kind, tracer.root.spanId)
+}
+
+func newSpan(name string, kind int, parentSpanId string) *Span {
+	return &Span{name: name, kind: kind, spanId: newId(8), parentSpanId: parentSpanId, start: time.Now(), attributes: map[string]interface{}{}}
+}
+
+// Sets an attribute of the span. The value should be a string, a bool or an integer. Empty strings are ignored.
+func (span *Span) SetAttribute(key string, value interface{}) {
+	if span == nil {
+		return
+	}
+	if str, ok := value.(string); ok && str == "" {
+		return
+	}
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+	span.attributes[key] = value
+}
+
+// Marks the span as failed, if err isn't nil.
+func (span *Span) SetError(err error) {
+	if span == nil || err == nil {
+		return
+	}
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+	span.statusCode = statusCodeError
+	span.statusMsg = redact.String(err.Error())
+}
+
+// Ends the span. The span is exported when the command ends.
+func (span *Span) End() {
+	if span == nil || tracer == nil {
+		return
+	}
+	span.mutex.Lock()
+	span.end = time.Now()
+	span.mutex.Unlock()
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+	tracer.ended = append(tracer.ended, span)
+}
+
+func getTracesEndpoint() string {
+	if endpoint := os.Getenv(TracesEndpointEnv); endpoint != "" {
+		return endpoint
+	}
+	if endpoint := os.Getenv(EndpointEnv); endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/") + tracesPath
+	}
+	return ""
+}
+
+// Returns the trace ID and the parent span ID of a W3C traceparent value, such as
+// '00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01'. Returns empty IDs if the value is invalid.
+func parseTraceParent(traceParent string) (traceId, parentSpanId string) {
+	parts := strings.Split(strings.TrimSpace(traceParent), "-")
+	if len(parts) < 4 || len(parts[1]) != 32 || len(parts[2]) != 16 || !isHex(parts[1]) || !isHex(parts[2]) {
+		return "", ""
+	}
+	return strings.ToLower(parts[1]), strings.ToLower(parts[2])
+}
+
+func isHex(str string) bool {
+	_, err := hex.DecodeString(str)
+	return err == nil
+}
+
+// Returns a random ID of the given number of bytes, as a hex string.
+func newId(size int) string {
+	id := make([]byte, size)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}